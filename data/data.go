@@ -0,0 +1,30 @@
+// Package data contains the default VADER lexicon files built into the binary.
+package data
+
+import (
+	_ "embed"
+)
+
+// VaderLexicon is the content of vader_lexicon.txt
+//
+//go:embed vader_lexicon.txt
+var VaderLexicon string
+
+// EmojiLexicon is the content of emoji_utf8_lexicon.txt
+//
+//go:embed emoji_utf8_lexicon.txt
+var EmojiLexicon string
+
+// SpanishLexicon is the content of es_lexicon.txt, a hand-curated Spanish
+// valence lexicon in VADER layout with mean valences only, it has no standard
+// deviations and raw ratings as it was not rated by a panel of raters
+//
+//go:embed es_lexicon.txt
+var SpanishLexicon string
+
+// EmojiValenceLexicon is the content of emoji_valence_lexicon.txt, a hand-curated
+// lexicon of emoji valences in VADER layout with mean valences only, it has no
+// standard deviations and raw ratings as it was not rated by a panel of raters
+//
+//go:embed emoji_valence_lexicon.txt
+var EmojiValenceLexicon string
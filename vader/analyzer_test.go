@@ -0,0 +1,85 @@
+package vader
+
+import (
+	"sync"
+	"testing"
+)
+
+// run with -race to detect data races
+func TestAnalyzer_Concurrent(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := make([]SentimentScores, len(sentences))
+	for i, sentence := range sentences {
+		expected[i] = sia.PolarityScores(sentence)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+
+			for i, sentence := range sentences {
+				if scores := sia.PolarityScores(sentence); scores != expected[i] {
+					t.Errorf("%s: %+v != %+v", sentence, scores, expected[i])
+				}
+				sia.ExplainPolarityScores(sentence)
+				sia.DocumentPolarityScores(sentence, AggregateMean)
+			}
+
+			// deriving analyzers concurrently doesn't affect the shared one
+			derived, err := sia.With(WithNegations("nary"), WithoutBoosters("very"), WithSentimentLadenIdiom("over the moon", float64(g)))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			derived.PolarityScores("nary very good")
+		}(g)
+	}
+	wg.Wait()
+
+	for i, sentence := range sentences {
+		if scores := sia.PolarityScores(sentence); scores != expected[i] {
+			t.Errorf("%s: %+v != %+v", sentence, scores, expected[i])
+		}
+	}
+}
+
+func TestAnalyzer_With(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options := DefaultOptions()
+	options.BIncr = 1
+	derived, err := sia.With(WithBooster("mega", 0.5), WithOptions(options))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if derived.Boosters()["very"] != 1 || derived.Boosters()["mega"] != 0.5 {
+		t.Errorf("unexpected derived boosters: very=%f mega=%f", derived.Boosters()["very"], derived.Boosters()["mega"])
+	}
+	if sia.Boosters()["very"] != B_INCR || sia.Options() != DefaultOptions() {
+		t.Error("parent analyzer changed by With")
+	}
+	if _, ok := sia.Boosters()["mega"]; ok {
+		t.Error("booster leaked to parent analyzer")
+	}
+}
+
+func TestAnalyzer_ZeroValue(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for zero value analyzer")
+		}
+	}()
+
+	var sia Analyzer
+	sia.PolarityScores("good")
+}
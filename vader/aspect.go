@@ -0,0 +1,191 @@
+package vader
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// punctuation which ends a clause when it ends a word
+const clauseDelimiters = ",;:.!?"
+
+// AspectScores are polarity scores of a target term of a text, computed from
+// sentiment-bearing tokens assigned to the target
+type AspectScores struct {
+	Target string `json:"target"`
+	SentimentScores
+	// Mentions is number of occurrences of the target in the text
+	Mentions int `json:"mentions"`
+	// Tokens are explanations of tokens assigned to the target, which support its scores
+	Tokens []TokenExplanation `json:"tokens"`
+}
+
+// target occurrence spanning tokens [start, end)
+type mention struct {
+	target     int
+	start, end int
+	clause     int
+}
+
+// AspectPolarityScores scores each of target terms or phrases of the text separately,
+// e.g. "battery" and "screen" of "the battery is great but the screen is awful".
+//
+// Valences of tokens are computed as by PolarityScores, so negations and contrastive
+// conjunctions apply, and each sentiment-bearing token is assigned to the nearest
+// mention of a target in the same clause. Clauses are delimited by punctuation such as
+// commas and semicolons and by contrastive conjunctions of the language. Tokens of
+// clauses without a target and tokens of targets themselves are not assigned.
+// Targets are matched case-insensitively; scores are returned in order of targets.
+func (sia *Analyzer) AspectPolarityScores(text string, targets []string) []AspectScores {
+	tr := &tracer{}
+	sentiments, text := sia.tokenSentiments(text, tr, nil)
+
+	words := make([]string, len(tr.tokens))
+	for i, token := range tr.tokens {
+		words[i] = strings.TrimFunc(strings.ToLower(token.Token), isPunctuation)
+	}
+	clauses, clauseTexts := sia.clauses(text, len(words))
+	mentions := findMentions(words, clauses, targets)
+
+	// nearest mention in the same clause of each token, -1 if none
+	assigned := make([]int, len(words))
+	for i := range words {
+		assigned[i] = -1
+		nearest := len(words)
+		for m, mention := range mentions {
+			if mention.clause != clauses[i] {
+				continue
+			}
+			if i >= mention.start && i < mention.end {
+				assigned[i] = -1
+				break
+			}
+			distance := mention.start - i
+			if i >= mention.end {
+				distance = i - mention.end + 1
+			}
+			// ties go to the mention before the token
+			if distance < nearest || (distance == nearest && i >= mention.end) {
+				nearest = distance
+				assigned[i] = m
+			}
+		}
+	}
+
+	aspects := make([]AspectScores, len(targets))
+	for t, target := range targets {
+		aspects[t].Target = target
+
+		mentioned := make(map[int]bool)
+		for _, mention := range mentions {
+			if mention.target == t {
+				aspects[t].Mentions++
+				mentioned[mention.clause] = true
+			}
+		}
+		if aspects[t].Mentions == 0 {
+			continue
+		}
+
+		// tokens of clauses mentioning the target, not assigned tokens are neutral
+		var targetSentiments []float64
+		for i := range words {
+			if !mentioned[clauses[i]] {
+				continue
+			}
+			if assigned[i] >= 0 && mentions[assigned[i]].target == t && sentiments[i] != 0 {
+				targetSentiments = append(targetSentiments, sentiments[i])
+				aspects[t].Tokens = append(aspects[t].Tokens, tr.tokens[i])
+			} else {
+				targetSentiments = append(targetSentiments, 0)
+			}
+		}
+
+		var targetText []string
+		for clause, clauseText := range clauseTexts {
+			if mentioned[clause] {
+				targetText = append(targetText, clauseText)
+			}
+		}
+		aspects[t].SentimentScores = sia.scoreValence(targetSentiments, strings.Join(targetText, " "))
+	}
+
+	return aspects
+}
+
+// clause index of each of n tokens of text and text of each clause,
+// text is a single clause if its words cannot be matched to tokens
+func (sia *Analyzer) clauses(text string, n int) ([]int, []string) {
+	clauses := make([]int, 0, n)
+	var clauseTexts []string
+
+	clause := 0
+	for _, field := range strings.Fields(text) {
+		tokens := sia.tokenize(field)
+		for _, token := range tokens {
+			if len(clauses) > 0 && containsWord(sia.language.ContrastiveConjunctions, strings.ToLower(token)) {
+				clause++
+			}
+			clauses = append(clauses, clause)
+		}
+
+		for len(clauseTexts) <= clause {
+			clauseTexts = append(clauseTexts, "")
+		}
+		clauseTexts[clause] = strings.TrimSpace(clauseTexts[clause] + " " + field)
+
+		last, _ := utf8.DecodeLastRuneInString(field)
+		if strings.ContainsRune(clauseDelimiters, last) && (len(tokens) == 0 || !sia.isEmoticon(tokens[len(tokens)-1])) {
+			clause++
+		}
+	}
+
+	if len(clauses) != n {
+		return make([]int, n), []string{text}
+	}
+
+	return clauses, clauseTexts
+}
+
+// non-overlapping mentions of targets in words in order of position, longer targets are matched first
+func findMentions(words []string, clauses []int, targets []string) []mention {
+	order := make([]int, len(targets))
+	targetWords := make([][]string, len(targets))
+	for t, target := range targets {
+		order[t] = t
+		targetWords[t] = strings.Fields(normalizePhrase(target))
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(targetWords[order[i]]) > len(targetWords[order[j]])
+	})
+
+	var mentions []mention
+	taken := make([]bool, len(words))
+	for _, t := range order {
+		phrase := targetWords[t]
+		if len(phrase) == 0 {
+			continue
+		}
+
+	positions:
+		for start := 0; start+len(phrase) <= len(words); start++ {
+			for i, word := range phrase {
+				// a phrase doesn't span clauses
+				if taken[start+i] || words[start+i] != word || clauses[start+i] != clauses[start] {
+					continue positions
+				}
+			}
+
+			for i := range phrase {
+				taken[start+i] = true
+			}
+			mentions = append(mentions, mention{target: t, start: start, end: start + len(phrase), clause: clauses[start]})
+		}
+	}
+
+	sort.Slice(mentions, func(i, j int) bool {
+		return mentions[i].start < mentions[j].start
+	})
+
+	return mentions
+}
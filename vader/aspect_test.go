@@ -0,0 +1,70 @@
+package vader
+
+import (
+	"testing"
+)
+
+func TestAnalyzer_AspectPolarityScores(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aspects := sia.AspectPolarityScores("The battery is great but the screen is awful", []string{"battery", "Screen", "price"})
+	if len(aspects) != 3 || aspects[0].Target != "battery" || aspects[1].Target != "Screen" {
+		t.Fatalf("unexpected aspects: %+v", aspects)
+	}
+	if aspects[0].Compound <= 0 || aspects[1].Compound >= 0 {
+		t.Errorf("unexpected aspect scores: %+v", aspects)
+	}
+	if len(aspects[0].Tokens) != 1 || aspects[0].Tokens[0].Token != "great" || aspects[1].Tokens[0].Token != "awful" {
+		t.Errorf("unexpected supporting tokens: %+v, %+v", aspects[0].Tokens, aspects[1].Tokens)
+	}
+	if aspects[2].Mentions != 0 || aspects[2].Compound != 0 || len(aspects[2].Tokens) != 0 {
+		t.Errorf("unexpected score of missing target: %+v", aspects[2])
+	}
+
+	tests := []struct {
+		text     string
+		targets  []string
+		expected []int // sign of compound score of each target
+	}{
+		{"The battery life is not good, the screen is amazing!", []string{"battery life", "screen"}, []int{-1, 1}},
+		{"Great camera and terrible battery", []string{"camera", "battery"}, []int{1, -1}},
+		{"The screen is fine; I hate the weather", []string{"screen"}, []int{1}},
+		{"I love it. The battery is good, the case is ugly", []string{"battery", "case"}, []int{1, -1}},
+		{"The screen protector is awful, the screen is great", []string{"screen", "screen protector"}, []int{1, -1}},
+	}
+
+	for _, test := range tests {
+		aspects := sia.AspectPolarityScores(test.text, test.targets)
+		for i, aspect := range aspects {
+			if sign(aspect.Compound) != test.expected[i] {
+				t.Errorf("unexpected score of %q in %q: %+v", aspect.Target, test.text, aspect.SentimentScores)
+			}
+			if aspect.Mentions != 1 {
+				t.Errorf("unexpected mentions of %q in %q: %d", aspect.Target, test.text, aspect.Mentions)
+			}
+		}
+	}
+
+	spanish, err := New(WithLanguage(Spanish()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	aspects = spanish.AspectPolarityScores("La batería es excelente pero la pantalla es horrible", []string{"batería", "pantalla"})
+	if aspects[0].Compound <= 0 || aspects[1].Compound >= 0 {
+		t.Errorf("unexpected Spanish aspect scores: %+v", aspects)
+	}
+}
+
+func sign(f float64) int {
+	switch {
+	case f > 0:
+		return 1
+	case f < 0:
+		return -1
+	}
+
+	return 0
+}
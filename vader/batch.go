@@ -0,0 +1,181 @@
+package vader
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// ErrTextTooLong is reported for texts longer than BatchOptions.MaxTextLength
+var ErrTextTooLong = errors.New("vader: text exceeds maximum length")
+
+// BatchOptions configure concurrent batch scoring
+type BatchOptions struct {
+	// Workers is number of goroutines scoring texts, runtime.GOMAXPROCS(0) if not positive
+	Workers int
+	// MaxTextLength is max length of a text in bytes, longer texts are not scored
+	// and reported with ErrTextTooLong; no limit if not positive
+	MaxTextLength int
+}
+
+// BatchResult is polarity scores of a text at Index of the input,
+// Err is set if the text was not scored
+type BatchResult struct {
+	Index int `json:"index"`
+	SentimentScores
+	Err error `json:"-"`
+}
+
+// a text to score and channel to deliver its result to
+type batchJob struct {
+	index  int
+	text   string
+	result chan<- BatchResult
+}
+
+func (o BatchOptions) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+
+	return runtime.GOMAXPROCS(0)
+}
+
+func (sia *Analyzer) batchResult(index int, text string, opts BatchOptions) BatchResult {
+	if opts.MaxTextLength > 0 && len(text) > opts.MaxTextLength {
+		return BatchResult{Index: index, Err: ErrTextTooLong}
+	}
+
+	return BatchResult{Index: index, SentimentScores: sia.PolarityScores(text)}
+}
+
+// BatchPolarityScores scores texts concurrently and returns results in input order.
+// When ctx is cancelled scoring stops promptly and ctx error is returned
+// along with results, in which texts not scored yet have ctx error set.
+func (sia *Analyzer) BatchPolarityScores(ctx context.Context, texts []string, opts BatchOptions) ([]BatchResult, error) {
+	results := make([]BatchResult, len(texts))
+	scored := make([]bool, len(texts))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < opts.workers(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				if ctx.Err() != nil {
+					return
+				}
+				// each worker writes distinct indexes
+				results[i] = sia.batchResult(i, texts[i], opts)
+				scored[i] = true
+			}
+		}()
+	}
+
+feed:
+	for i := range texts {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		for i := range results {
+			if !scored[i] {
+				results[i] = BatchResult{Index: i, Err: err}
+			}
+		}
+		return results, err
+	}
+
+	return results, nil
+}
+
+// StreamPolarityScores scores texts received from channel concurrently and
+// sends results in input order to returned channel. Returned channel is closed
+// after texts channel is closed and all results are delivered, or when ctx is cancelled.
+func (sia *Analyzer) StreamPolarityScores(ctx context.Context, texts <-chan string, opts BatchOptions) <-chan BatchResult {
+	out := make(chan BatchResult)
+	jobs := make(chan batchJob)
+	// results in input order, bounded by number of workers
+	pending := make(chan chan BatchResult, opts.workers())
+
+	var wg sync.WaitGroup
+	for w := 0; w < opts.workers(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for job := range jobs {
+				if ctx.Err() != nil {
+					return
+				}
+				job.result <- sia.batchResult(job.index, job.text, opts)
+			}
+		}()
+	}
+
+	// dispatch texts to workers
+	go func() {
+		defer func() {
+			close(jobs)
+			close(pending)
+		}()
+
+		for index := 0; ; index++ {
+			var text string
+			var ok bool
+			select {
+			case text, ok = <-texts:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			result := make(chan BatchResult, 1)
+			select {
+			case pending <- result:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case jobs <- batchJob{index: index, text: text, result: result}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// deliver results in input order
+	go func() {
+		defer func() {
+			wg.Wait()
+			close(out)
+		}()
+
+		for result := range pending {
+			select {
+			case r := <-result:
+				select {
+				case out <- r:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,116 @@
+package vader
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzer_BatchPolarityScores(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	texts := append([]string{strings.Repeat("good ", 100)}, sentences...)
+	results, err := sia.BatchPolarityScores(context.Background(), texts, BatchOptions{Workers: 4, MaxTextLength: 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != len(texts) {
+		t.Fatalf("expected %d results, got %d", len(texts), len(results))
+	}
+	if !errors.Is(results[0].Err, ErrTextTooLong) {
+		t.Errorf("expected ErrTextTooLong, got %v", results[0].Err)
+	}
+	for i, result := range results[1:] {
+		if result.Index != i+1 || result.Err != nil || result.SentimentScores != sia.PolarityScores(texts[i+1]) {
+			t.Errorf("unexpected result for %q: %+v", texts[i+1], result)
+		}
+	}
+}
+
+func TestAnalyzer_BatchPolarityScores_Cancel(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := sia.BatchPolarityScores(ctx, sentences, BatchOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	for i, result := range results {
+		if result.Index != i || (result.Err != nil && !errors.Is(result.Err, context.Canceled)) {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	}
+}
+
+func TestAnalyzer_StreamPolarityScores(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	texts := make(chan string)
+	go func() {
+		defer close(texts)
+		for i := 0; i < 10; i++ {
+			for _, sentence := range sentences {
+				texts <- sentence
+			}
+		}
+	}()
+
+	index := 0
+	for result := range sia.StreamPolarityScores(context.Background(), texts, BatchOptions{Workers: 3}) {
+		sentence := sentences[index%len(sentences)]
+		if result.Index != index || result.Err != nil || result.SentimentScores != sia.PolarityScores(sentence) {
+			t.Errorf("unexpected result for %q: %+v", sentence, result)
+		}
+		index++
+	}
+
+	if index != 10*len(sentences) {
+		t.Errorf("expected %d results, got %d", 10*len(sentences), index)
+	}
+}
+
+func TestAnalyzer_StreamPolarityScores_Cancel(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// texts channel is never closed, results channel is closed on cancel
+	texts := make(chan string)
+	go func() {
+		for {
+			select {
+			case texts <- "good":
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := sia.StreamPolarityScores(ctx, texts, BatchOptions{Workers: 2})
+	for result := range results {
+		if result.Index == 100 {
+			cancel()
+			break
+		}
+	}
+
+	for range results {
+	}
+}
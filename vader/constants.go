@@ -23,11 +23,15 @@ const (
 //Match all undesirable punctuation
 var PunctuationRegexp = regexp.MustCompile(`[\\\!\"\/\$\%\&\'\#\(\)\*\+\,\-\.\:\;\<\=\>\?\@\[\]\^\_\{\|\}\~\x60]`)
 
-//Match emoji characters
-var EmojisRegexp = regexp.MustCompile(`[\x{1F300}-\x{1F6FF}|[\x{2600}-\x{26FF}]`)
+//Match emoji characters: pictographs, regional indicators of flags and keycap mark
+var EmojisRegexp = regexp.MustCompile(`[\x{1F000}-\x{1FAFF}\x{1FC00}-\x{1FFFD}\x{2600}-\x{27BF}\x{2B00}-\x{2BFF}\x{2190}-\x{21FF}\x{2300}-\x{23FF}\x{20E3}\x{00A9}\x{00AE}\x{203C}\x{2049}\x{2122}\x{2139}\x{24C2}\x{25AA}-\x{25FE}\x{2934}\x{2935}\x{3030}\x{303D}\x{3297}\x{3299}]`)
 var PositivePercentageRegexp = regexp.MustCompile(`(\(|\s)*(\+(\d+|\d+(\.|\,)\d+)(\%|\s\%))(\)|\s)*`)
 var NegativePercentageRegexp = regexp.MustCompile(`(\(|\s)*(\-(\d+|\d+(\.|\,)\d+)(\%|\s\%))(\)|\s)*`)
 
+// Negations are the default English negation words.
+//
+// Deprecated: changes have no effect, analyzers use copy made at package initialization.
+// Use WithNegations and WithoutNegations, or Negations of English() language pack.
 var Negations = []string{"aint", "arent", "cannot", "cant", "couldnt", "darent", "didnt", "doesnt",
 	"ain't", "aren't", "can't", "couldn't", "daren't", "didn't", "doesn't",
 	"dont", "hadnt", "hasnt", "havent", "isnt", "mightnt", "mustnt", "neither",
@@ -37,6 +41,10 @@ var Negations = []string{"aint", "arent", "cannot", "cant", "couldnt", "darent",
 	"oughtn't", "shan't", "shouldn't", "uh-uh", "wasn't", "weren't",
 	"without", "wont", "wouldnt", "won't", "wouldn't", "rarely", "seldom", "despite"}
 
+// BoosterMap is the default English table of boosters and dampeners.
+//
+// Deprecated: changes have no effect, analyzers use copy made at package initialization.
+// Use WithBooster and WithoutBoosters, or Boosters of English() language pack.
 var BoosterMap = map[string]float64{"absolutely": B_INCR, "amazingly": B_INCR, "awfully": B_INCR, "completely": B_INCR,
 	"considerably": B_INCR, "decidedly": B_INCR, "deeply": B_INCR, "effing": B_INCR, "enormously": B_INCR,
 	"entirely": B_INCR, "especially": B_INCR, "exceptionally": B_INCR, "extremely": B_INCR, "fabulously": B_INCR,
@@ -52,6 +60,10 @@ var BoosterMap = map[string]float64{"absolutely": B_INCR, "amazingly": B_INCR, "
 	"too": B_INCR, "sort-of": B_DECR, "big": B_INCR, "biggest": B_INCR, "small": B_DECR,
 }
 
+// SentimentLadenIdioms are the default English idioms which carry sentiment without lexicon words.
+//
+// Deprecated: changes have no effect, analyzers use copy made at package initialization.
+// Use WithSentimentLadenIdiom and WithoutSentimentLadenIdioms, or SentimentLadenIdioms of English() language pack.
 var SentimentLadenIdioms = map[string]int{
 	"cut the mustard":   2,
 	"hand to mouth":     -2,
@@ -68,6 +80,9 @@ var SentimentLadenIdioms = map[string]int{
 }
 
 // special case idioms map containing lexicon words
+//
+// Deprecated: changes have no effect, analyzers use copy made at package initialization.
+// Use WithSpecialCaseIdiom and WithoutSpecialCaseIdioms, or SpecialCaseIdioms of English() language pack.
 var SpecialCaseIdioms = map[string]float64{
 	"the shit":        3,
 	"the bomb":        3,
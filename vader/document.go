@@ -0,0 +1,211 @@
+package vader
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gonum/floats"
+)
+
+// Aggregation strategy used to combine sentence scores into a document score
+type Aggregation int
+
+const (
+	// AggregateMean gives every sentence the same weight
+	AggregateMean Aggregation = iota
+	// AggregateLengthWeighted weights sentences by number of tokens
+	AggregateLengthWeighted
+	// AggregateExtremesWeighted weights sentences by absolute compound score,
+	// so strongly polarized sentences dominate neutral ones
+	AggregateExtremesWeighted
+)
+
+// Sentence of a document, Start and End are byte offsets in the document,
+// so that document[Start:End] == Text
+type Sentence struct {
+	Text  string `json:"text"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// SentenceScores is a sentence with its polarity scores
+type SentenceScores struct {
+	Sentence
+	SentimentScores
+}
+
+// DocumentScores is aggregated document score with per-sentence scores
+type DocumentScores struct {
+	SentimentScores
+	Sentences []SentenceScores `json:"sentences"`
+}
+
+// common abbreviations followed by period which doesn't end a sentence
+var sentenceAbbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "sr": true, "jr": true, "st": true,
+	"vs": true, "etc": true, "e.g": true, "i.e": true, "approx": true, "inc": true, "ltd": true, "co": true,
+	"corp": true, "vol": true, "fig": true, "jan": true, "feb": true, "mar": true, "apr": true,
+	"jun": true, "jul": true, "aug": true, "sep": true, "sept": true, "oct": true, "nov": true, "dec": true,
+}
+
+// abbreviations which don't end a sentence only when followed by a number, e.g. "No. 5",
+// as they are common words at the end of a sentence, e.g. "they said no."
+var numberAbbreviations = map[string]bool{"no": true}
+
+// Score each sentence of the text separately, so that rules like "but" and
+// punctuation emphasis don't leak between unrelated sentences,
+// and aggregate sentence scores into a document score.
+func (sia *Analyzer) DocumentPolarityScores(text string, aggregation Aggregation) *DocumentScores {
+	sentences := SplitSentences(text)
+
+	document := &DocumentScores{Sentences: make([]SentenceScores, 0, len(sentences))}
+	weights := make([]float64, 0, len(sentences))
+	for _, sentence := range sentences {
+		scores := sia.PolarityScores(sentence.Text)
+		document.Sentences = append(document.Sentences, SentenceScores{Sentence: sentence, SentimentScores: scores})
+
+		switch aggregation {
+		case AggregateLengthWeighted:
+			weights = append(weights, float64(len(sia.tokenize(sentence.Text))))
+		case AggregateExtremesWeighted:
+			weights = append(weights, math.Abs(scores.Compound))
+		default:
+			weights = append(weights, 1)
+		}
+	}
+
+	totalWeight := floats.Sum(weights)
+	if totalWeight == 0 {
+		// e.g. only neutral sentences in extremes weighted aggregation
+		for i := range weights {
+			weights[i] = 1
+		}
+		totalWeight = float64(len(weights))
+	}
+
+	if totalWeight == 0 {
+		return document
+	}
+
+	var neg, neu, pos, compound float64
+	for i, sentence := range document.Sentences {
+		weight := weights[i] / totalWeight
+		neg += sentence.Neg * weight
+		neu += sentence.Neu * weight
+		pos += sentence.Pos * weight
+		compound += sentence.Compound * weight
+	}
+
+	document.SentimentScores = SentimentScores{
+		Neg:      floats.Round(neg, 3),
+		Neu:      floats.Round(neu, 3),
+		Pos:      floats.Round(pos, 3),
+		Compound: floats.Round(compound, 4),
+	}
+
+	return document
+}
+
+// Split text into sentences.
+// Sentence ends with a run of terminators (. ! ? … and emoji) followed by whitespace,
+// periods of abbreviations, initials and decimals don't end a sentence,
+// neither do ellipses and emoji unless next sentence starts with uppercase letter.
+func SplitSentences(text string) []Sentence {
+	var sentences []Sentence
+
+	start := 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if !isSentenceTerminator(r) && sentenceEmojiLen(text[i:]) == 0 {
+			i += size
+			continue
+		}
+
+		// consume run of terminators, closing punctuation and emoji
+		end := i
+		for end < len(text) {
+			if n := sentenceEmojiLen(text[end:]); n > 0 {
+				end += n
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if !isSentenceTerminator(r) && !isClosingPunctuation(r) && !isEmojiExtension(r) && r != zeroWidthJoiner {
+				break
+			}
+			end += size
+		}
+
+		next := strings.TrimLeftFunc(text[end:], unicode.IsSpace)
+		if next == "" || (len(next) < len(text[end:]) && endsSentence(text[start:i], text[i:end], next)) {
+			sentences = appendSentence(sentences, text, start, end)
+			start = end
+		}
+		i = end
+	}
+
+	return appendSentence(sentences, text, start, len(text))
+}
+
+// append sentence text[start:end] without surrounding whitespace if it is not empty
+func appendSentence(sentences []Sentence, text string, start, end int) []Sentence {
+	sentence := text[start:end]
+	trimmed := strings.TrimLeftFunc(sentence, unicode.IsSpace)
+	start += len(sentence) - len(trimmed)
+	trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	if trimmed == "" {
+		return sentences
+	}
+
+	return append(sentences, Sentence{Text: trimmed, Start: start, End: start + len(trimmed)})
+}
+
+// decide whether terminators run followed by whitespace ends the sentence
+func endsSentence(before, terminators, next string) bool {
+	nextRune, _ := utf8.DecodeRuneInString(next)
+	startsUpper := unicode.IsUpper(nextRune)
+
+	switch {
+	case strings.ContainsAny(terminators, "!?！？。"):
+		return true
+	case strings.Contains(terminators, "..") || strings.Contains(terminators, "…"):
+		return startsUpper
+	case strings.Contains(terminators, "."):
+		fields := strings.Fields(before)
+		if len(fields) > 0 {
+			word := strings.ToLower(strings.TrimLeftFunc(fields[len(fields)-1], unicode.IsPunct))
+			if sentenceAbbreviations[word] || utf8.RuneCountInString(word) == 1 ||
+				(numberAbbreviations[word] && unicode.IsDigit(nextRune)) {
+				return false
+			}
+		}
+		return !unicode.IsLower(nextRune)
+	default:
+		// emoji only
+		return startsUpper
+	}
+}
+
+func isSentenceTerminator(r rune) bool {
+	switch r {
+	case '.', '!', '?', '…', '！', '？', '。':
+		return true
+	}
+	return false
+}
+
+func isClosingPunctuation(r rune) bool {
+	return unicode.In(r, unicode.Pe, unicode.Pf) || r == '"' || r == '\''
+}
+
+// length in bytes of emoji cluster at start of s which can end a sentence, 0 if there is none.
+// Symbols such as © or ↔ are emoji only in emoji presentation, i.e. followed by variation selector 16.
+func sentenceEmojiLen(s string) int {
+	n := emojiClusterLen(s)
+	if r, _ := utf8.DecodeRuneInString(s); n > 0 && pictographs[r] && !emojiPresentation[r] && !strings.ContainsRune(s[:n], variationSelector16) {
+		return 0
+	}
+
+	return n
+}
@@ -0,0 +1,75 @@
+package vader
+
+import (
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	var splitSentencesTexts = map[string][]string{
+		"The book was good. The movie was bad!":                 {"The book was good.", "The movie was bad!"},
+		"Dr. Smith paid $3.50 for it, i.e. nothing. Great deal": {"Dr. Smith paid $3.50 for it, i.e. nothing.", "Great deal"},
+		"J. R. R. Tolkien wrote it. Really?! Yes.":              {"J. R. R. Tolkien wrote it.", "Really?!", "Yes."},
+		"Well... maybe not. I waited… Nothing happened":         {"Well... maybe not.", "I waited…", "Nothing happened"},
+		"Loved it 😍 The staff was great 👍🏽 would come again":    {"Loved it 😍", "The staff was great 👍🏽 would come again"},
+		"He said \"stop.\" Then he left.":                       {"He said \"stop.\"", "Then he left."},
+		"Go team 🇺🇸 See you at the game":                        {"Go team 🇺🇸", "See you at the game"},
+		"Press 1️⃣ Then wait":                                   {"Press 1️⃣", "Then wait"},
+		"Copyright © Acme Corp":                                 {"Copyright © Acme Corp"},
+		"Time to go ⏰ Bye":                                      {"Time to go ⏰", "Bye"},
+		"They said no. Terrible service! Never again.":          {"They said no.", "Terrible service!", "Never again."},
+		"Room No. 5 was clean":                                  {"Room No. 5 was clean"},
+		"  ":                                                    nil,
+	}
+
+	for text, expected := range splitSentencesTexts {
+		sentences := SplitSentences(text)
+		if len(sentences) != len(expected) {
+			t.Errorf("%q: expected %d sentences, got %+v", text, len(expected), sentences)
+			continue
+		}
+
+		for i, sentence := range sentences {
+			if sentence.Text != expected[i] || text[sentence.Start:sentence.End] != sentence.Text {
+				t.Errorf("%q: unexpected sentence %d: %+v", text, i, sentence)
+			}
+		}
+	}
+}
+
+func TestAnalyzer_DocumentPolarityScores(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "The hotel was fine, but the room was tiny. The staff were absolutely wonderful!!! Breakfast was at 8."
+
+	document := sia.DocumentPolarityScores(text, AggregateMean)
+	if len(document.Sentences) != 3 {
+		t.Fatalf("expected 3 sentences, got %+v", document.Sentences)
+	}
+
+	// "but" of the first sentence doesn't amplify the second one
+	second := document.Sentences[1]
+	if second.SentimentScores != sia.PolarityScores("The staff were absolutely wonderful!!!") {
+		t.Errorf("sentence scored differently than standalone text: %+v", second)
+	}
+
+	mean := 0.0
+	for _, sentence := range document.Sentences {
+		mean += sentence.Compound / 3
+	}
+	if document.Compound < mean-0.0001 || document.Compound > mean+0.0001 {
+		t.Errorf("expected mean compound %f, got %f", mean, document.Compound)
+	}
+
+	weighted := sia.DocumentPolarityScores(text, AggregateLengthWeighted)
+	extremes := sia.DocumentPolarityScores(text, AggregateExtremesWeighted)
+	if extremes.Compound <= document.Compound || extremes.Compound <= weighted.Compound {
+		t.Errorf("extremes weighted compound %f should dominate mean %f and length weighted %f", extremes.Compound, document.Compound, weighted.Compound)
+	}
+
+	if empty := sia.DocumentPolarityScores("", AggregateMean); len(empty.Sentences) != 0 || empty.SentimentScores != (SentimentScores{}) {
+		t.Errorf("unexpected scores of empty document: %+v", empty)
+	}
+}
@@ -0,0 +1,242 @@
+package vader
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/drankou/go-vader/data"
+)
+
+const (
+	zeroWidthJoiner      = '\u200D'
+	variationSelector15  = '\uFE0E' // text presentation
+	variationSelector16  = '\uFE0F' // emoji presentation
+	combiningKeycap      = '\u20E3'
+	cancelTag            = '\U000E007F'
+	regionalIndicatorA   = '\U0001F1E6'
+	regionalIndicatorZ   = '\U0001F1FF'
+	skinToneLight        = '\U0001F3FB'
+	skinToneDark         = '\U0001F3FF'
+	tagSpace             = '\U000E0020'
+	tagTilde             = '\U000E007E'
+	pictographicsStart   = '\U0001F000'
+	pictographicsEnd     = '\U0001FAFF'
+	miscSymbolsStart     = '\u2600' // miscellaneous symbols and dingbats
+	miscSymbolsEnd       = '\u27BF'
+	otherPictographStart = '\U0001FC00'
+	otherPictographEnd   = '\U0001FFFD'
+)
+
+// pictographs out of emoji blocks, such as © ™ ↔ ⌚ ⭐
+var pictographs = map[rune]bool{
+	0x00A9: true, 0x00AE: true, 0x203C: true, 0x2049: true, 0x2122: true, 0x2139: true,
+	0x2194: true, 0x2195: true, 0x2196: true, 0x2197: true, 0x2198: true, 0x2199: true,
+	0x21A9: true, 0x21AA: true, 0x231A: true, 0x231B: true, 0x2328: true, 0x23CF: true,
+	0x23E9: true, 0x23EA: true, 0x23EB: true, 0x23EC: true, 0x23ED: true, 0x23EE: true,
+	0x23EF: true, 0x23F0: true, 0x23F1: true, 0x23F2: true, 0x23F3: true, 0x23F8: true,
+	0x23F9: true, 0x23FA: true, 0x24C2: true, 0x25AA: true, 0x25AB: true, 0x25B6: true,
+	0x25C0: true, 0x25FB: true, 0x25FC: true, 0x25FD: true, 0x25FE: true, 0x2934: true,
+	0x2935: true, 0x2B05: true, 0x2B06: true, 0x2B07: true, 0x2B1B: true, 0x2B1C: true,
+	0x2B50: true, 0x2B55: true, 0x3030: true, 0x303D: true, 0x3297: true, 0x3299: true,
+}
+
+// pictographs out of emoji blocks which are shown as emoji by default, others are text symbols
+// unless followed by variation selector 16
+var emojiPresentation = map[rune]bool{
+	0x231A: true, 0x231B: true, 0x23E9: true, 0x23EA: true, 0x23EB: true, 0x23EC: true,
+	0x23F0: true, 0x23F3: true, 0x25FD: true, 0x25FE: true, 0x2B1B: true, 0x2B1C: true,
+	0x2B50: true, 0x2B55: true,
+}
+
+// emoji which can start emoji sequence
+func isPictograph(r rune) bool {
+	return (r >= pictographicsStart && r <= pictographicsEnd && !isRegionalIndicator(r)) ||
+		(r >= miscSymbolsStart && r <= miscSymbolsEnd) ||
+		(r >= otherPictographStart && r <= otherPictographEnd) ||
+		pictographs[r]
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= regionalIndicatorA && r <= regionalIndicatorZ
+}
+
+func isSkinTone(r rune) bool {
+	return r >= skinToneLight && r <= skinToneDark
+}
+
+// characters which modify preceding emoji: variation selectors, skin tones and tags
+func isEmojiExtension(r rune) bool {
+	return r == variationSelector15 || r == variationSelector16 || isSkinTone(r) ||
+		(r >= tagSpace && r <= tagTilde) || r == cancelTag || r == combiningKeycap
+}
+
+// SplitEmoji splits token into emoji grapheme clusters and runs of other text between them.
+// Clusters are emoji with skin tones and variation selectors, zero width joiner sequences
+// such as 👨‍👩‍👧, flags of regional indicator pairs or tag sequences and keycaps such as 1️⃣.
+func SplitEmoji(token string) []string {
+	var parts []string
+
+	start := 0
+	for i := 0; i < len(token); {
+		if n := emojiClusterLen(token[i:]); n > 0 {
+			if start < i {
+				parts = append(parts, token[start:i])
+			}
+			parts = append(parts, token[i:i+n])
+			i += n
+			start = i
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(token[i:])
+		i += size
+	}
+	if start < len(token) {
+		parts = append(parts, token[start:])
+	}
+
+	return parts
+}
+
+// length in bytes of emoji cluster at start of s, 0 if s doesn't start with emoji
+func emojiClusterLen(s string) int {
+	r, size := utf8.DecodeRuneInString(s)
+
+	switch {
+	case isRegionalIndicator(r):
+		if next, nextSize := utf8.DecodeRuneInString(s[size:]); isRegionalIndicator(next) {
+			return size + nextSize
+		}
+		return size
+	case (r >= '0' && r <= '9') || r == '#' || r == '*':
+		n := size
+		if next, nextSize := utf8.DecodeRuneInString(s[n:]); next == variationSelector16 {
+			n += nextSize
+		}
+		if next, nextSize := utf8.DecodeRuneInString(s[n:]); next == combiningKeycap {
+			return n + nextSize
+		}
+		return 0
+	case isPictograph(r):
+		n := size + emojiExtensionsLen(s[size:])
+		for {
+			joiner, joinerSize := utf8.DecodeRuneInString(s[n:])
+			if joiner != zeroWidthJoiner {
+				return n
+			}
+			next, nextSize := utf8.DecodeRuneInString(s[n+joinerSize:])
+			if !isPictograph(next) {
+				return n
+			}
+			n += joinerSize + nextSize
+			n += emojiExtensionsLen(s[n:])
+		}
+	}
+
+	return 0
+}
+
+func emojiExtensionsLen(s string) int {
+	n := 0
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if !isEmojiExtension(r) {
+			break
+		}
+		n += size
+	}
+
+	return n
+}
+
+// NormalizeEmoji removes skin tones and variation selectors from emoji,
+// e.g. 👍🏽 is normalized to 👍
+func NormalizeEmoji(emoji string) string {
+	return strings.Map(func(r rune) rune {
+		if r == variationSelector15 || r == variationSelector16 || isSkinTone(r) {
+			return -1
+		}
+		return r
+	}, emoji)
+}
+
+// descriptions of emoji lexicon by normalized emoji, for emoji which normalized form is not in lexicon
+func emojiAliases(emojiLexicon map[string]string) map[string]string {
+	aliases := make(map[string]string)
+	for emoji := range emojiLexicon {
+		normalized := NormalizeEmoji(emoji)
+		if _, ok := emojiLexicon[normalized]; ok || normalized == emoji {
+			continue
+		}
+		// prefer description of the shortest variant, i.e. without skin tone
+		if alias, ok := aliases[normalized]; !ok || len(emoji) < len(alias) || (len(emoji) == len(alias) && emoji < alias) {
+			aliases[normalized] = emoji
+		}
+	}
+
+	for normalized, emoji := range aliases {
+		aliases[normalized] = emojiLexicon[emoji]
+	}
+
+	return aliases
+}
+
+// DefaultEmojiValences returns emoji valence lexicon built into the package
+func DefaultEmojiValences() LexiconLayer {
+	layer, err := ReadLexiconLayer("emoji_valence_lexicon.txt", strings.NewReader(data.EmojiValenceLexicon))
+	if err != nil {
+		panic(err)
+	}
+
+	return layer
+}
+
+// WithEmojiValences scores emoji as single tokens with valences of emoji valence lexicon,
+// which is stacked as a layer over the lexicon, instead of replacing them with
+// descriptions of emoji lexicon. Emoji missing in valence lexicon are still described.
+// Emoji valence lexicon can be read with ReadLexiconLayer.
+func WithEmojiValences(emojiValences LexiconLayer) Option {
+	layer := WithLexiconLayers(emojiValences)
+	return func(b *builder) error {
+		if err := layer(b); err != nil {
+			return err
+		}
+
+		b.edits = append(b.edits, func(sia *Analyzer) {
+			sia.emojiValences = true
+		})
+		return nil
+	}
+}
+
+// lexicon token of emoji scored by emoji valence, emoji is looked up without skin tones
+// and variation selectors, exact emoji only if normalized one is not in lexicon
+func (sia *Analyzer) emojiToken(emoji string) (string, bool) {
+	if emoji == "" || emojiClusterLen(emoji) != len(emoji) {
+		return "", false
+	}
+
+	normalized := NormalizeEmoji(emoji)
+	if _, ok := sia.lexicon[normalized]; ok {
+		return normalized, true
+	}
+	_, ok := sia.lexicon[emoji]
+
+	return emoji, ok
+}
+
+// description of emoji, emoji is looked up without skin tones and variation selectors,
+// so that skin tone variants score as base emoji; exact emoji only if normalized one
+// is not in lexicon, e.g. 🏳️‍🌈 which needs variation selector
+func (sia *Analyzer) emojiDescription(emoji string) (string, bool) {
+	normalized := NormalizeEmoji(emoji)
+	if description, ok := sia.emojiLexicon[normalized]; ok {
+		return description, true
+	}
+	if description, ok := sia.emojiLexicon[emoji]; ok {
+		return description, true
+	}
+	description, ok := sia.emojiAliases[normalized]
+
+	return description, ok
+}
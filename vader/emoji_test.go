@@ -0,0 +1,136 @@
+package vader
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitEmoji(t *testing.T) {
+	tests := map[string][]string{
+		"😀😁":       {"😀", "😁"},
+		"🤣🥰":       {"🤣", "🥰"}, // supplemental symbols and pictographs
+		"👍🏽👍":      {"👍🏽", "👍"},
+		"👨‍👩‍👧":    {"👨‍👩‍👧"},
+		"🏳️‍🌈":     {"🏳️‍🌈"},
+		"👩🏽‍💻":     {"👩🏽‍💻"},
+		"🇺🇸🇬🇧":     {"🇺🇸", "🇬🇧"},
+		"🏴󠁧󠁢󠁳󠁣󠁴󠁿":  {"🏴󠁧󠁢󠁳󠁣󠁴󠁿"},
+		"1️⃣#⃣":    {"1️⃣", "#⃣"},
+		"❤️❤":      {"❤️", "❤"},
+		"love💘you": {"love", "💘", "you"},
+		"2020!":    {"2020!"},
+		"👍‍":       {"👍", "‍"}, // dangling joiner
+	}
+
+	for token, expected := range tests {
+		if parts := SplitEmoji(token); !reflect.DeepEqual(parts, expected) {
+			t.Errorf("unexpected emoji of %q: %q, expected %q", token, parts, expected)
+		}
+	}
+}
+
+func TestNormalizeEmoji(t *testing.T) {
+	tests := map[string]string{
+		"👍🏽":   "👍",
+		"❤️":   "❤",
+		"🏳️‍🌈": "🏳‍🌈",
+		"👩🏿‍💻": "👩‍💻",
+		"😀":    "😀",
+	}
+
+	for emoji, expected := range tests {
+		if normalized := NormalizeEmoji(emoji); normalized != expected {
+			t.Errorf("unexpected normalized %q: %q, expected %q", emoji, normalized, expected)
+		}
+	}
+}
+
+func TestAnalyzer_PolarityScores_EmojiSequences(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// sequences are described as a whole, not by their parts
+	for _, emoji := range []string{"👩‍💻", "🏳️‍🌈"} {
+		explanation := sia.ExplainPolarityScores(emoji)
+		var words []string
+		for _, token := range explanation.Tokens {
+			words = append(words, token.Token)
+		}
+		if description := strings.Join(words, " "); description != sia.emojiLexicon[emoji] {
+			t.Errorf("unexpected description of %s: %q", emoji, description)
+		}
+	}
+
+	// skin tone variants in lexicon are scored as base emoji
+	for text, base := range map[string]string{"great 👍🏿": "great 👍", "Bye 👋🏽!": "Bye 👋!", "👎🏻👎🏻": "👎👎"} {
+		if sia.PolarityScores(text) != sia.PolarityScores(base) {
+			t.Errorf("%q scores %+v, %q scores %+v", text, sia.PolarityScores(text), base, sia.PolarityScores(base))
+		}
+	}
+
+	emojiLexicon := "👍\tgood\n🏳️‍🌈\tgood pride\n"
+	custom, err := New(WithLexiconReaders(strings.NewReader("good\t1.9\npride\t1.3\n"), strings.NewReader(emojiLexicon)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// skin tones and variation selectors missing in lexicon are ignored
+	tests := map[string]string{
+		"Nice 👍🏾":   "Nice good",
+		"Nice 👍️":   "Nice good",
+		"Nice 🏳‍🌈":  "Nice good pride",
+		"Nice🏳️‍🌈!": "Nice good pride !",
+	}
+	for text, described := range tests {
+		if custom.PolarityScores(text) != custom.PolarityScores(described) {
+			t.Errorf("emoji of %q are not described as %q", text, described)
+		}
+	}
+}
+
+func TestWithEmojiValences(t *testing.T) {
+	descriptions, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sia, err := descriptions.With(WithEmojiValences(DefaultEmojiValences()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// emoji is a single token with its own valence
+	explanation := sia.ExplainPolarityScores("I passed 😁")
+	if len(explanation.Tokens) != 3 {
+		t.Fatalf("unexpected tokens: %+v", explanation.Tokens)
+	}
+	if emoji := explanation.Tokens[2]; emoji.Token != "😁" || !emoji.InLexicon || emoji.LexiconValence != 2.5 {
+		t.Errorf("unexpected emoji token: %+v", emoji)
+	}
+	if layer, _ := sia.LexiconSource("😁"); layer != "emoji_valence_lexicon.txt" {
+		t.Errorf("unexpected source of emoji valence: %q", layer)
+	}
+
+	// descriptions inflate neutral proportion
+	if scores := sia.PolarityScores("😁"); scores.Pos != 1 || scores.Neu != 0 {
+		t.Errorf("unexpected scores of single emoji: %+v", scores)
+	}
+	if descriptions.PolarityScores("😁").Neu == 0 {
+		t.Error("emoji scored by valence in description mode")
+	}
+
+	// skin tones and variation selectors are normalized
+	if sia.PolarityScores("Ok 👍🏽") != sia.PolarityScores("Ok 👍") || sia.PolarityScores("I ❤️ it") != sia.PolarityScores("I ❤ it") {
+		t.Error("emoji variants scored differently")
+	}
+
+	// emoji without valence are still described
+	if _, ok := sia.LookupLexicon("🦄"); ok {
+		t.Fatal("unexpected valence of 🦄")
+	}
+	if sia.PolarityScores("🦄") != descriptions.PolarityScores("🦄") {
+		t.Error("emoji without valence is not described")
+	}
+}
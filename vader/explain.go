@@ -0,0 +1,94 @@
+package vader
+
+// Names of heuristic rules recorded in token explanations
+const (
+	RuleNo           = "no"            // "no" used as negation of adjacent lexicon item
+	RuleAllCaps      = "allcaps"       // emphasis of word in ALL CAPS
+	RuleBooster      = "booster"       // preceding booster or dampener word
+	RuleNegation     = "negation"      // preceding negation
+	RuleSpecialIdiom = "special_idiom" // special case idiom containing lexicon words
+	RuleBut          = "but"           // contrastive conjunction "but"
+
+	RuleSentimentLadenIdiom = "sentiment_laden_idiom" // idiom that doesn't contain a lexicon word
+)
+
+// RuleApplication records how a rule changed valence of a token
+type RuleApplication struct {
+	Rule   string  `json:"rule"`
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+}
+
+// TokenExplanation describes how valence of a single token was computed
+type TokenExplanation struct {
+	Token          string            `json:"token"`
+	InLexicon      bool              `json:"in_lexicon"`
+	LexiconValence float64           `json:"lexicon_valence"` // raw valence from lexicon
+	Valence        float64           `json:"valence"`         // final valence after all rules
+	Rules          []RuleApplication `json:"rules,omitempty"` // rules that changed valence, in order
+}
+
+// Explanation of polarity scores of a text
+type Explanation struct {
+	SentimentScores
+	// Amplifier added to sum of valences due to exclamation points and question marks
+	PunctuationEmphasis float64            `json:"punctuation_emphasis"`
+	Tokens              []TokenExplanation `json:"tokens"`
+}
+
+// Return the same scores as PolarityScores along with per-token trace
+// of lexicon valences and rules that changed them.
+func (sia *Analyzer) ExplainPolarityScores(text string) *Explanation {
+	tr := &tracer{}
+	sentiments, text := sia.tokenSentiments(text, tr, nil)
+
+	return &Explanation{
+		SentimentScores:     sia.scoreValence(sentiments, text),
+		PunctuationEmphasis: sia.punctuationEmphasis(text),
+		Tokens:              tr.tokens,
+	}
+}
+
+// tracer collects token explanations during scoring,
+// all methods are no-op on nil tracer
+type tracer struct {
+	tokens []TokenExplanation
+}
+
+func (t *tracer) init(tokens []string) {
+	if t == nil {
+		return
+	}
+
+	t.tokens = make([]TokenExplanation, len(tokens))
+	for i, token := range tokens {
+		t.tokens[i].Token = token
+	}
+}
+
+func (t *tracer) lexicon(index int, valence float64) {
+	if t == nil {
+		return
+	}
+
+	t.tokens[index].InLexicon = true
+	t.tokens[index].LexiconValence = valence
+}
+
+func (t *tracer) record(index int, rule string, before, after float64) {
+	if t == nil || before == after {
+		return
+	}
+
+	t.tokens[index].Rules = append(t.tokens[index].Rules, RuleApplication{Rule: rule, Before: before, After: after})
+}
+
+func (t *tracer) finish(sentiments []float64) {
+	if t == nil {
+		return
+	}
+
+	for i, sentiment := range sentiments {
+		t.tokens[i].Valence = sentiment
+	}
+}
@@ -0,0 +1,242 @@
+package vader
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/drankou/go-vader/data"
+)
+
+// LanguagePack bundles lexicons and grammar words the rules use for a language.
+// Booster values equal to B_INCR and B_DECR are replaced by booster increment
+// and decrement of analyzer options, as for the default English boosters.
+type LanguagePack struct {
+	// Code is language code, e.g. "en"
+	Code string `json:"code"`
+
+	// valence lexicon and emoji lexicon content in VADER layout,
+	// emoji are left as they are if emoji lexicon is empty
+	Lexicon      string `json:"lexicon,omitempty"`
+	EmojiLexicon string `json:"emoji_lexicon,omitempty"`
+
+	Negations            []string           `json:"negations"`
+	Boosters             map[string]float64 `json:"boosters"`
+	SpecialCaseIdioms    map[string]float64 `json:"special_case_idioms"`
+	SentimentLadenIdioms map[string]float64 `json:"sentiment_laden_idioms"`
+
+	// ContrastiveConjunctions shift weight of sentiment to the clause after them, e.g. "but"
+	ContrastiveConjunctions []string `json:"contrastive_conjunctions"`
+	// NeutralPhrases are phrases which first word is never scored, e.g. "kind of"
+	NeutralPhrases []string `json:"neutral_phrases"`
+	// ConditionalNegations negate unless preceded by one of given words, e.g. "least" unless "at least"
+	ConditionalNegations map[string][]string `json:"conditional_negations"`
+	// EmphaticNegations intensify instead of negating when followed by one of given words, e.g. "never so"
+	EmphaticNegations map[string][]string `json:"emphatic_negations"`
+	// NegationCancellers are two-word phrases which keep valence of the following word, e.g. "without doubt"
+	NegationCancellers []string `json:"negation_cancellers"`
+	// NegationSuffix negates words it ends when analyzer option IncludeNt is set, e.g. "n't"
+	NegationSuffix string `json:"negation_suffix"`
+	// NegativeDeterminer negates next words instead of having its own valence, e.g. "no",
+	// also across a disjunction, e.g. "no fun or joy"
+	NegativeDeterminer string   `json:"negative_determiner"`
+	Disjunctions       []string `json:"disjunctions"`
+
+	// names of lexicons in parse errors and lexicon layers
+	lexiconName, emojiLexiconName string
+}
+
+// English language pack shared by analyzers, it is never modified
+var english = English()
+
+// copies of exported default English tables made at package initialization,
+// so that changes of the exported tables have no effect
+var (
+	englishNegations            = append([]string(nil), Negations...)
+	englishBoosters             = copyTable(BoosterMap)
+	englishSpecialCaseIdioms    = copyTable(SpecialCaseIdioms)
+	englishSentimentLadenIdioms = intTable(SentimentLadenIdioms)
+)
+
+// English returns the default English language pack of VADER
+func English() *LanguagePack {
+	return &LanguagePack{
+		Code:                    "en",
+		Lexicon:                 data.VaderLexicon,
+		EmojiLexicon:            data.EmojiLexicon,
+		Negations:               append([]string(nil), englishNegations...),
+		Boosters:                copyTable(englishBoosters),
+		SpecialCaseIdioms:       copyTable(englishSpecialCaseIdioms),
+		SentimentLadenIdioms:    copyTable(englishSentimentLadenIdioms),
+		ContrastiveConjunctions: []string{"but"},
+		NeutralPhrases:          []string{"kind of"},
+		ConditionalNegations:    map[string][]string{"least": {"at", "very"}},
+		EmphaticNegations:       map[string][]string{"never": {"so", "this"}},
+		NegationCancellers:      []string{"without doubt"},
+		NegationSuffix:          "n't",
+		NegativeDeterminer:      "no",
+		Disjunctions:            []string{"or", "nor"},
+		lexiconName:             "vader_lexicon.txt",
+		emojiLexiconName:        "emoji_utf8_lexicon.txt",
+	}
+}
+
+// Spanish returns Spanish language pack with hand-curated lexicon,
+// it has no emoji lexicon, as emoji descriptions are English
+func Spanish() *LanguagePack {
+	return &LanguagePack{
+		Code:      "es",
+		Lexicon:   data.SpanishLexicon,
+		Negations: []string{"no", "nunca", "jamás", "ni", "nada", "nadie", "ningún", "ninguna", "ninguno", "tampoco", "sin"},
+		Boosters: map[string]float64{"muy": B_INCR, "mucho": B_INCR, "muchísimo": B_INCR, "bastante": B_INCR,
+			"demasiado": B_INCR, "extremadamente": B_INCR, "increíblemente": B_INCR, "realmente": B_INCR,
+			"totalmente": B_INCR, "completamente": B_INCR, "súper": B_INCR, "super": B_INCR, "tan": B_INCR,
+			"tremendamente": B_INCR, "sumamente": B_INCR, "absolutamente": B_INCR, "más": B_INCR,
+			"poco": B_DECR, "apenas": B_DECR, "algo": B_DECR, "ligeramente": B_DECR, "un poco": B_DECR,
+			"casi": B_DECR, "medio": B_DECR, "más o menos": B_DECR, "menos": B_DECR},
+		SpecialCaseIdioms:       map[string]float64{"a toda madre": 3, "de maravilla": 3, "para morirse": 3, "qué va": -1.5},
+		SentimentLadenIdioms:    map[string]float64{"mala leche": -2, "buena onda": 2, "pan comido": 1.5, "ni fu ni fa": -0.5},
+		ContrastiveConjunctions: []string{"pero", "sino"},
+		EmphaticNegations:       map[string][]string{"nunca": {"tan"}},
+		NegationCancellers:      []string{"sin duda"},
+		lexiconName:             "es_lexicon.txt",
+		emojiLexiconName:        "es emoji lexicon",
+	}
+}
+
+// WithLanguage builds analyzer for language pack, replacing lexicons and tables,
+// other lexicon and table options are applied over the pack
+func WithLanguage(pack *LanguagePack) Option {
+	return func(b *builder) error {
+		if pack == nil {
+			return errors.New("vader: nil language pack")
+		}
+		if pack.Code == "" {
+			return errors.New("vader: language pack without code")
+		}
+		for _, canceller := range pack.NegationCancellers {
+			if len(strings.Fields(canceller)) != 2 {
+				return errors.New("vader: negation canceller must have two words: " + canceller)
+			}
+		}
+
+		b.language = pack.clone()
+		return nil
+	}
+}
+
+// Language returns code of analyzer language
+func (sia *Analyzer) Language() string {
+	return sia.language.Code
+}
+
+// lexicons of the language pack
+func (pack *LanguagePack) source() *lexiconSource {
+	lexiconName, emojiLexiconName := pack.lexiconName, pack.emojiLexiconName
+	if lexiconName == "" {
+		lexiconName = pack.Code + " lexicon"
+	}
+	if emojiLexiconName == "" {
+		emojiLexiconName = pack.Code + " emoji lexicon"
+	}
+
+	return &lexiconSource{lexiconName, pack.Lexicon, emojiLexiconName, pack.EmojiLexicon}
+}
+
+// copy of pack with grammar words normalized as tokens are matched,
+// so that it is not modified through caller's pack
+func (pack *LanguagePack) clone() *LanguagePack {
+	clone := *pack
+	clone.Negations = normalizePhrases(pack.Negations)
+	clone.Boosters = normalizeTable(pack.Boosters)
+	clone.SpecialCaseIdioms = normalizeTable(pack.SpecialCaseIdioms)
+	clone.SentimentLadenIdioms = normalizeTable(pack.SentimentLadenIdioms)
+	clone.ContrastiveConjunctions = normalizePhrases(pack.ContrastiveConjunctions)
+	clone.NeutralPhrases = normalizePhrases(pack.NeutralPhrases)
+	clone.NegationCancellers = normalizePhrases(pack.NegationCancellers)
+	clone.NegationSuffix = strings.ToLower(pack.NegationSuffix)
+	clone.NegativeDeterminer = normalizePhrase(pack.NegativeDeterminer)
+	clone.Disjunctions = normalizePhrases(pack.Disjunctions)
+
+	clone.ConditionalNegations = make(map[string][]string, len(pack.ConditionalNegations))
+	for word, exceptions := range pack.ConditionalNegations {
+		clone.ConditionalNegations[normalizePhrase(word)] = normalizePhrases(exceptions)
+	}
+	clone.EmphaticNegations = make(map[string][]string, len(pack.EmphaticNegations))
+	for word, followers := range pack.EmphaticNegations {
+		clone.EmphaticNegations[normalizePhrase(word)] = normalizePhrases(followers)
+	}
+
+	return &clone
+}
+
+// copy of pack without lexicons, lexicons of analyzer are saved in lexicon state apart
+func (pack *LanguagePack) grammar() *LanguagePack {
+	grammar := pack.clone()
+	grammar.Lexicon, grammar.EmojiLexicon = "", ""
+	grammar.lexiconName, grammar.emojiLexiconName = "", ""
+
+	return grammar
+}
+
+func intTable(table map[string]int) map[string]float64 {
+	converted := make(map[string]float64, len(table))
+	for phrase, value := range table {
+		converted[phrase] = float64(value)
+	}
+
+	return converted
+}
+
+func normalizePhrases(phrases []string) []string {
+	normalized := make([]string, len(phrases))
+	for i, phrase := range phrases {
+		normalized[i] = normalizePhrase(phrase)
+	}
+
+	return normalized
+}
+
+func normalizeTable(table map[string]float64) map[string]float64 {
+	normalized := make(map[string]float64, len(table))
+	for phrase, value := range table {
+		normalized[normalizePhrase(phrase)] = value
+	}
+
+	return normalized
+}
+
+func containsWord(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+
+	return false
+}
+
+// check whether word at index starts one of neutral phrases
+func (pack *LanguagePack) startsNeutralPhrase(words []string, index int) bool {
+	for _, phrase := range pack.NeutralPhrases {
+		phraseWords := strings.Fields(phrase)
+		// the last word alone is scored as usual
+		if len(phraseWords) < 2 || index+len(phraseWords) > len(words) {
+			continue
+		}
+		if strings.Join(words[index:index+len(phraseWords)], " ") == phrase {
+			return true
+		}
+	}
+
+	return false
+}
+
+// check whether word is emphatic negation followed by an intensified word
+func (pack *LanguagePack) isEmphaticNegation(word, next string) bool {
+	return containsWord(pack.EmphaticNegations[word], next)
+}
+
+// check whether words are a negation canceller, e.g. "without doubt"
+func (pack *LanguagePack) isNegationCanceller(first, second string) bool {
+	return containsWord(pack.NegationCancellers, first+" "+second)
+}
@@ -0,0 +1,133 @@
+package vader
+
+import (
+	"testing"
+)
+
+func TestWithLanguage_Spanish(t *testing.T) {
+	sia, err := New(WithLanguage(Spanish()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sia.Language() != "es" {
+		t.Errorf("unexpected language: %q", sia.Language())
+	}
+
+	score := func(text string) float64 {
+		return sia.PolarityScores(text).Compound
+	}
+
+	if score("La comida es buena") <= 0 || score("La comida es horrible") >= 0 {
+		t.Error("unexpected polarity of Spanish sentences")
+	}
+	if score("¡Qué comida tan buena!") <= 0 {
+		t.Error("word after inverted exclamation mark is not scored")
+	}
+	if score("La comida es muy buena") <= score("La comida es buena") {
+		t.Error("booster 'muy' doesn't increase sentiment")
+	}
+	for _, text := range []string{"La comida no es buena", "No es bueno.", "No me gusta", "No es muy bueno"} {
+		if score(text) >= 0 {
+			t.Errorf("negation 'no' doesn't flip sentiment of %q", text)
+		}
+	}
+	if score("Sin duda excelente") <= 0 {
+		t.Error("'sin duda' is treated as negation")
+	}
+	if score("El servicio es excelente pero la comida es horrible") >= 0 {
+		t.Error("contrastive conjunction 'pero' doesn't shift sentiment")
+	}
+	if score("Esta película es pan comido") <= 0 {
+		t.Error("sentiment laden idiom 'pan comido' is not scored")
+	}
+	// English words are not recognized
+	if score("This food is very good") != 0 {
+		t.Error("English sentence scored by Spanish analyzer")
+	}
+}
+
+func TestWithLanguage_Custom(t *testing.T) {
+	german := &LanguagePack{
+		Code:                    "de",
+		Lexicon:                 "gut\t1.9\t0.5\nschlecht\t-2.0\t0.6\n",
+		Negations:               []string{"nicht", "kein"},
+		Boosters:                map[string]float64{"sehr": B_INCR},
+		ContrastiveConjunctions: []string{"aber"},
+	}
+
+	english, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sia, err := english.With(WithLanguage(german), WithBooster("echt", 0.3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sia.Language() != "de" || english.Language() != "en" {
+		t.Errorf("unexpected languages: %q, %q", sia.Language(), english.Language())
+	}
+
+	if sia.PolarityScores("Das ist sehr gut").Compound <= sia.PolarityScores("Das ist gut").Compound {
+		t.Error("booster 'sehr' doesn't increase sentiment")
+	}
+	if sia.PolarityScores("Das ist echt gut").Compound <= sia.PolarityScores("Das ist gut").Compound {
+		t.Error("booster added over language pack doesn't increase sentiment")
+	}
+	if sia.PolarityScores("Das ist nicht gut").Compound >= 0 {
+		t.Error("negation 'nicht' doesn't flip sentiment")
+	}
+	if _, ok := sia.Boosters()["very"]; ok {
+		t.Error("English booster in German analyzer")
+	}
+	if layer, _ := sia.LexiconSource("gut"); layer != "de lexicon" {
+		t.Errorf("unexpected lexicon layer: %q", layer)
+	}
+
+	// the pack is copied by the option
+	german.Negations[0] = "doch"
+	if !sia.isNegation("nicht") {
+		t.Error("analyzer modified through language pack")
+	}
+
+	for _, pack := range []*LanguagePack{nil, {}, {Code: "de", NegationCancellers: []string{"ohne"}}} {
+		if _, err := New(WithLanguage(pack)); err == nil {
+			t.Errorf("expected error for language pack %+v", pack)
+		}
+	}
+}
+
+func TestEnglish(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	explicit, err := New(WithLanguage(English()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sia.Language() != "en" {
+		t.Errorf("unexpected default language: %q", sia.Language())
+	}
+	for _, sentence := range sentences {
+		if sia.PolarityScores(sentence) != explicit.PolarityScores(sentence) {
+			t.Errorf("different scores of explicit English analyzer for %q", sentence)
+		}
+	}
+}
+
+func TestEnglish_ExportedTables(t *testing.T) {
+	negations, boosters := Negations, BoosterMap
+	defer func() {
+		Negations, BoosterMap = negations, boosters
+	}()
+	Negations = []string{"totally"}
+	BoosterMap = map[string]float64{}
+
+	if containsWord(English().Negations, "totally") || len(English().Boosters) == 0 {
+		t.Error("English uses modified exported tables")
+	}
+	if !ContainsNegation([]string{"not", "good"}) || ContainsNegation([]string{"totally", "good"}) {
+		t.Error("ContainsNegation uses modified exported negations")
+	}
+}
@@ -0,0 +1,144 @@
+package vader
+
+import (
+	"io"
+	"io/ioutil"
+	"sort"
+)
+
+// LiveLayer is the layer name reported for tokens edited by LiveAnalyzer
+const LiveLayer = "live"
+
+// LexiconLayer is a named set of lexicon entries stacked over lower layers,
+// entries override or extend lower layers and neutralized tokens are removed from them
+type LexiconLayer struct {
+	Name        string         `json:"name"`
+	Entries     []LexiconEntry `json:"entries"`
+	Neutralized []string       `json:"neutralized,omitempty"`
+}
+
+// LexiconConflict records token defined in a lower layer and redefined
+// or neutralized by a higher one
+type LexiconConflict struct {
+	Token         string        `json:"token"`
+	Layer         string        `json:"layer"`
+	PreviousLayer string        `json:"previous_layer"`
+	Previous      LexiconEntry  `json:"previous"`
+	Entry         *LexiconEntry `json:"entry,omitempty"` // nil if token was neutralized
+}
+
+// ReadLexiconLayer reads layer entries in VADER lexicon format,
+// standard deviation and raw ratings columns are optional
+func ReadLexiconLayer(name string, r io.Reader) (LexiconLayer, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return LexiconLayer{}, err
+	}
+
+	entries, _, err := MakeLexiconEntries(name, string(content), Strict)
+	if err != nil {
+		return LexiconLayer{}, err
+	}
+
+	layer := LexiconLayer{Name: name, Entries: make([]LexiconEntry, 0, len(entries))}
+	for _, entry := range entries {
+		layer.Entries = append(layer.Entries, entry)
+	}
+	sort.Slice(layer.Entries, func(i, j int) bool {
+		return layer.Entries[i].Token < layer.Entries[j].Token
+	})
+
+	return layer, nil
+}
+
+// WithLexiconLayers stacks layers in given order over the analyzer lexicon,
+// later layers take precedence
+func WithLexiconLayers(layers ...LexiconLayer) Option {
+	return edit(func(sia *Analyzer) {
+		sia.stackLayers(layers...)
+	})
+}
+
+func (sia *Analyzer) stackLayers(layers ...LexiconLayer) {
+	// lexicon may be shared with other analyzers, so it is replaced instead of modified
+	lexicon := make(map[string]LexiconEntry, len(sia.lexicon))
+	for token, entry := range sia.lexicon {
+		lexicon[token] = entry
+	}
+	origins := make(map[string]string, len(sia.lexiconOrigins))
+	for token, layer := range sia.lexiconOrigins {
+		origins[token] = layer
+	}
+	conflicts := append([]LexiconConflict(nil), sia.lexiconConflicts...)
+
+	for _, layer := range layers {
+		for _, token := range layer.Neutralized {
+			if previous, ok := lexicon[token]; ok {
+				conflicts = append(conflicts, LexiconConflict{Token: token, Layer: layer.Name, PreviousLayer: sia.origin(origins, token), Previous: previous})
+				delete(lexicon, token)
+			}
+			origins[token] = layer.Name
+		}
+
+		for _, entry := range layer.Entries {
+			entry = entry.clone()
+			if previous, ok := lexicon[entry.Token]; ok {
+				conflictEntry := entry.clone()
+				conflicts = append(conflicts, LexiconConflict{Token: entry.Token, Layer: layer.Name, PreviousLayer: sia.origin(origins, entry.Token), Previous: previous, Entry: &conflictEntry})
+			}
+			lexicon[entry.Token] = entry
+			origins[entry.Token] = layer.Name
+		}
+
+		sia.lexiconLayers = append(append([]string(nil), sia.lexiconLayers...), layer.Name)
+	}
+
+	sia.lexicon = lexicon
+	sia.lexiconOrigins = origins
+	sia.lexiconConflicts = conflicts
+}
+
+// layer which supplied token according to origins, base lexicon by default
+func (sia *Analyzer) origin(origins map[string]string, token string) string {
+	if layer, ok := origins[token]; ok {
+		return layer
+	}
+
+	return sia.lexiconLayers[0]
+}
+
+// LexiconLayers returns names of lexicon layers from the base lexicon up
+func (sia *Analyzer) LexiconLayers() []string {
+	return append([]string(nil), sia.lexiconLayers...)
+}
+
+// LexiconSource returns name of the layer which supplied value of token,
+// false if token is not in lexicon
+func (sia *Analyzer) LexiconSource(token string) (string, bool) {
+	if _, ok := sia.lexicon[token]; !ok {
+		return "", false
+	}
+
+	return sia.origin(sia.lexiconOrigins, token), true
+}
+
+// LexiconConflicts returns tokens which higher layers redefined or neutralized, in order of layers
+func (sia *Analyzer) LexiconConflicts() []LexiconConflict {
+	conflicts := make([]LexiconConflict, len(sia.lexiconConflicts))
+	for i, conflict := range sia.lexiconConflicts {
+		conflicts[i] = conflict.clone()
+	}
+
+	return conflicts
+}
+
+// copy of conflict which doesn't share entries
+func (c LexiconConflict) clone() LexiconConflict {
+	c.Previous = c.Previous.clone()
+	if c.Entry != nil {
+		entry := c.Entry.clone()
+		c.Entry = &entry
+	}
+
+	return c
+}
@@ -0,0 +1,118 @@
+package vader
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithLexiconLayers(t *testing.T) {
+	domain, err := ReadLexiconLayer("finance", strings.NewReader("bull\t1.5\nbear\t-1.5\ncrash\t-3.0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	corrections := LexiconLayer{
+		Name:        "corrections",
+		Entries:     []LexiconEntry{{Token: "crash", Mean: -3.5}},
+		Neutralized: []string{"fine"},
+	}
+
+	sia, err := New(WithLexiconLayers(domain, corrections))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if layers := sia.LexiconLayers(); !reflect.DeepEqual(layers, []string{"vader_lexicon.txt", "finance", "corrections"}) {
+		t.Errorf("unexpected layers: %v", layers)
+	}
+
+	sources := map[string]string{"good": "vader_lexicon.txt", "bull": "finance", "crash": "corrections"}
+	for token, expected := range sources {
+		if layer, ok := sia.LexiconSource(token); !ok || layer != expected {
+			t.Errorf("unexpected source of %q: %q, %v", token, layer, ok)
+		}
+	}
+	if entry, _ := sia.LookupLexicon("crash"); entry.Mean != -3.5 {
+		t.Errorf("unexpected valence of 'crash': %v", entry.Mean)
+	}
+	if _, ok := sia.LexiconSource("fine"); ok {
+		t.Error("neutralized 'fine' is still in lexicon")
+	}
+	if sia.PolarityScores("fine").Compound != 0 {
+		t.Error("neutralized 'fine' still has valence")
+	}
+
+	// "crash" is in base lexicon, redefined by both layers, and "fine" is neutralized
+	conflicts := sia.LexiconConflicts()
+	if len(conflicts) != 3 {
+		t.Fatalf("unexpected conflicts: %+v", conflicts)
+	}
+	expected := []struct {
+		token, layer, previous string
+		neutralized            bool
+	}{
+		{"crash", "finance", "vader_lexicon.txt", false},
+		{"fine", "corrections", "vader_lexicon.txt", true},
+		{"crash", "corrections", "finance", false},
+	}
+	for i, e := range expected {
+		c := conflicts[i]
+		if c.Token != e.token || c.Layer != e.layer || c.PreviousLayer != e.previous || (c.Entry == nil) != e.neutralized {
+			t.Errorf("unexpected conflict %d: %+v", i, c)
+		}
+	}
+
+	// layers added with With are stacked over existing ones
+	extended, err := sia.With(WithLexiconLayers(LexiconLayer{Name: "slang", Entries: []LexiconEntry{{Token: "bull", Mean: -1}}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if layer, _ := extended.LexiconSource("bull"); layer != "slang" {
+		t.Errorf("unexpected source of 'bull': %q", layer)
+	}
+	if conflicts := extended.LexiconConflicts(); len(conflicts) != 4 || conflicts[3].PreviousLayer != "finance" {
+		t.Errorf("unexpected conflicts: %+v", conflicts)
+	}
+	if layer, _ := sia.LexiconSource("bull"); layer != "finance" {
+		t.Error("original analyzer modified")
+	}
+}
+
+func TestLiveAnalyzer_LexiconSource(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	live := NewLiveAnalyzer(sia)
+	if err := live.UpdateWord(LexiconEntry{Token: "sick", Mean: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	if layer, _ := live.Current().LexiconSource("sick"); layer != LiveLayer {
+		t.Errorf("unexpected source of 'sick': %q", layer)
+	}
+	if layer, _ := sia.LexiconSource("sick"); layer != "vader_lexicon.txt" {
+		t.Errorf("unexpected source of 'sick' in initial snapshot: %q", layer)
+	}
+
+	// removed and rolled back tokens get their previous sources
+	if err := live.AddWord(LexiconEntry{Token: "yeet", Mean: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := live.RemoveWord("yeet"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := live.Current().lexiconOrigins["yeet"]; ok {
+		t.Error("source of removed token is kept")
+	}
+	if err := live.Rollback(0); err != nil {
+		t.Fatal(err)
+	}
+	if layer, _ := live.Current().LexiconSource("sick"); layer != "vader_lexicon.txt" {
+		t.Errorf("unexpected source of 'sick' after rollback: %q", layer)
+	}
+	if len(live.Current().lexiconOrigins) != 0 {
+		t.Errorf("unexpected sources after rollback: %v", live.Current().lexiconOrigins)
+	}
+}
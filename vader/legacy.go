@@ -0,0 +1,206 @@
+package vader
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+// SentimentIntensityAnalyzer gives a sentiment intensity score to sentences.
+// It wraps Analyzer built by Init, which fills exported fields.
+// Changes of the fields after Init have no effect on scores.
+//
+// Deprecated: use New, which returns immutable Analyzer safe for concurrent use.
+type SentimentIntensityAnalyzer struct {
+	LexiconMap      map[string]float64
+	EmojiLexiconMap map[string]string
+
+	// Options of heuristics, validated by Init; zero value means DefaultOptions
+	Options Options
+
+	// ParseMode used by Init to load lexicons, Strict by default
+	ParseMode ParseMode
+	// SkippedLines lists malformed lexicon lines skipped in Lenient mode
+	SkippedLines []*ParseError
+
+	analyzer *Analyzer
+}
+
+// Initialize sentiment analyzer with lexicons
+// if no filepaths passed to init, using default lexicons built into the package,
+// otherwise exactly two filepaths are expected: valence lexicon and emoji lexicon
+//
+// Deprecated: use New with WithLexiconFiles.
+func (sia *SentimentIntensityAnalyzer) Init(filenames ...string) error {
+	switch len(filenames) {
+	case 0:
+		return sia.init()
+	case 2:
+		return sia.init(WithLexiconFiles(filenames[0], filenames[1]))
+	}
+
+	return fmt.Errorf("vader: expected 2 lexicon files (valence and emoji), got %d", len(filenames))
+}
+
+// Initialize sentiment analyzer with lexicons read from valence lexicon and emoji lexicon readers
+//
+// Deprecated: use New with WithLexiconReaders.
+func (sia *SentimentIntensityAnalyzer) InitFromReaders(lexiconReader, emojiLexiconReader io.Reader) error {
+	return sia.init(WithLexiconReaders(lexiconReader, emojiLexiconReader))
+}
+
+// Initialize sentiment analyzer with lexicons stored in fsys under given names
+//
+// Deprecated: use New with WithLexiconFS.
+func (sia *SentimentIntensityAnalyzer) InitFromFS(fsys fs.FS, lexiconName, emojiLexiconName string) error {
+	return sia.init(WithLexiconFS(fsys, lexiconName, emojiLexiconName))
+}
+
+// build analyzer with options and parse mode of the fields and fill the fields
+func (sia *SentimentIntensityAnalyzer) init(opts ...Option) error {
+	options := sia.Options
+	if options == (Options{}) {
+		options = DefaultOptions()
+	}
+
+	analyzer, err := New(append([]Option{WithOptions(options), WithParseMode(sia.ParseMode)}, opts...)...)
+	if err != nil {
+		return err
+	}
+
+	sia.Options = options
+	sia.setAnalyzer(analyzer)
+	return nil
+}
+
+func (sia *SentimentIntensityAnalyzer) setAnalyzer(analyzer *Analyzer) {
+	sia.analyzer = analyzer
+
+	sia.LexiconMap = make(map[string]float64, len(analyzer.lexicon))
+	for token, entry := range analyzer.lexicon {
+		sia.LexiconMap[token] = entry.Mean
+	}
+	sia.EmojiLexiconMap = make(map[string]string, len(analyzer.emojiLexicon))
+	for emoji, description := range analyzer.emojiLexicon {
+		sia.EmojiLexiconMap[emoji] = description
+	}
+	sia.SkippedLines = analyzer.SkippedLines()
+}
+
+// derive analyzer with opts, options of tables cannot fail
+func (sia *SentimentIntensityAnalyzer) with(opts ...Option) {
+	if analyzer, err := sia.analyzer.With(opts...); err == nil {
+		sia.setAnalyzer(analyzer)
+	}
+}
+
+// Analyzer returns analyzer built by Init
+func (sia *SentimentIntensityAnalyzer) Analyzer() *Analyzer {
+	return sia.analyzer
+}
+
+// Return a float for sentiment strength based on the input text.
+// Positive values are positive valence, negative value are negative valence.
+//
+// Deprecated: use Analyzer.PolarityScores.
+func (sia *SentimentIntensityAnalyzer) PolarityScores(text string) SentimentScores {
+	return sia.analyzer.PolarityScores(text)
+}
+
+// ExplainPolarityScores scores text and records rules applied to each token.
+//
+// Deprecated: use Analyzer.ExplainPolarityScores.
+func (sia *SentimentIntensityAnalyzer) ExplainPolarityScores(text string) *Explanation {
+	return sia.analyzer.ExplainPolarityScores(text)
+}
+
+// DocumentPolarityScores scores each sentence of text and aggregates them.
+//
+// Deprecated: use Analyzer.DocumentPolarityScores.
+func (sia *SentimentIntensityAnalyzer) DocumentPolarityScores(text string, aggregation Aggregation) *DocumentScores {
+	return sia.analyzer.DocumentPolarityScores(text, aggregation)
+}
+
+// Negations returns sorted negation words of the analyzer
+//
+// Deprecated: use Analyzer.Negations.
+func (sia *SentimentIntensityAnalyzer) Negations() []string {
+	return sia.analyzer.Negations()
+}
+
+// AddNegations adds negation words to the analyzer
+//
+// Deprecated: use WithNegations.
+func (sia *SentimentIntensityAnalyzer) AddNegations(words ...string) {
+	sia.with(WithNegations(words...))
+}
+
+// RemoveNegations removes negation words from the analyzer
+//
+// Deprecated: use WithoutNegations.
+func (sia *SentimentIntensityAnalyzer) RemoveNegations(words ...string) {
+	sia.with(WithoutNegations(words...))
+}
+
+// Boosters returns copy of booster and dampener table of the analyzer
+//
+// Deprecated: use Analyzer.Boosters.
+func (sia *SentimentIntensityAnalyzer) Boosters() map[string]float64 {
+	return sia.analyzer.Boosters()
+}
+
+// AddBooster adds booster (positive value) or dampener (negative value) to the analyzer
+//
+// Deprecated: use WithBooster.
+func (sia *SentimentIntensityAnalyzer) AddBooster(booster string, value float64) {
+	sia.with(WithBooster(booster, value))
+}
+
+// RemoveBoosters removes boosters and dampeners from the analyzer
+//
+// Deprecated: use WithoutBoosters.
+func (sia *SentimentIntensityAnalyzer) RemoveBoosters(boosters ...string) {
+	sia.with(WithoutBoosters(boosters...))
+}
+
+// SpecialCaseIdioms returns copy of special case idioms table of the analyzer
+//
+// Deprecated: use Analyzer.SpecialCaseIdioms.
+func (sia *SentimentIntensityAnalyzer) SpecialCaseIdioms() map[string]float64 {
+	return sia.analyzer.SpecialCaseIdioms()
+}
+
+// AddSpecialCaseIdiom adds idiom which valence replaces valence of its words
+//
+// Deprecated: use WithSpecialCaseIdiom.
+func (sia *SentimentIntensityAnalyzer) AddSpecialCaseIdiom(idiom string, valence float64) {
+	sia.with(WithSpecialCaseIdiom(idiom, valence))
+}
+
+// RemoveSpecialCaseIdioms removes special case idioms from the analyzer
+//
+// Deprecated: use WithoutSpecialCaseIdioms.
+func (sia *SentimentIntensityAnalyzer) RemoveSpecialCaseIdioms(idioms ...string) {
+	sia.with(WithoutSpecialCaseIdioms(idioms...))
+}
+
+// SentimentLadenIdioms returns copy of sentiment laden idioms table of the analyzer
+//
+// Deprecated: use Analyzer.SentimentLadenIdioms.
+func (sia *SentimentIntensityAnalyzer) SentimentLadenIdioms() map[string]float64 {
+	return sia.analyzer.SentimentLadenIdioms()
+}
+
+// AddSentimentLadenIdiom adds multi-word idiom that carries sentiment, e.g. "under the weather"
+//
+// Deprecated: use WithSentimentLadenIdiom.
+func (sia *SentimentIntensityAnalyzer) AddSentimentLadenIdiom(idiom string, valence float64) {
+	sia.with(WithSentimentLadenIdiom(idiom, valence))
+}
+
+// RemoveSentimentLadenIdioms removes sentiment laden idioms from the analyzer
+//
+// Deprecated: use WithoutSentimentLadenIdioms.
+func (sia *SentimentIntensityAnalyzer) RemoveSentimentLadenIdioms(idioms ...string) {
+	sia.with(WithoutSentimentLadenIdioms(idioms...))
+}
@@ -0,0 +1,48 @@
+package vader
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSentimentIntensityAnalyzer_Init(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legacy := SentimentIntensityAnalyzer{}
+	if err := legacy.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if legacy.LexiconMap["good"] != 1.9 || legacy.EmojiLexiconMap["😁"] == "" || legacy.Options != DefaultOptions() {
+		t.Error("exported fields are not filled by Init")
+	}
+	for _, sentence := range sentences {
+		if legacy.PolarityScores(sentence) != sia.PolarityScores(sentence) {
+			t.Errorf("different scores of deprecated analyzer for %q", sentence)
+		}
+	}
+
+	legacy.AddNegations("nary")
+	legacy.AddBooster("hella", B_INCR)
+	if !reflect.DeepEqual(legacy.Negations(), legacy.Analyzer().Negations()) || legacy.Boosters()["hella"] != B_INCR {
+		t.Error("tables are not changed by deprecated methods")
+	}
+	if legacy.PolarityScores("nary good").Compound >= 0 {
+		t.Errorf("added negation is not applied: %+v", legacy.PolarityScores("nary good"))
+	}
+
+	if err := legacy.Init("only one file"); err == nil {
+		t.Error("expected error for single lexicon file")
+	}
+
+	lenient := SentimentIntensityAnalyzer{ParseMode: Lenient}
+	if err := lenient.InitFromReaders(strings.NewReader("good\t1.9\nbad\n"), strings.NewReader("")); err != nil {
+		t.Fatal(err)
+	}
+	if len(lenient.SkippedLines) != 1 || len(lenient.LexiconMap) != 1 {
+		t.Errorf("unexpected lexicon of lenient analyzer: %v, %v", lenient.LexiconMap, lenient.SkippedLines)
+	}
+}
@@ -0,0 +1,87 @@
+package vader
+
+import (
+	"math"
+	"sort"
+)
+
+// LexiconEntry is a line of VADER lexicon: token with mean valence,
+// standard deviation and raw ratings of human raters
+type LexiconEntry struct {
+	Token   string    `json:"token"`
+	Mean    float64   `json:"mean"`
+	StdDev  float64   `json:"std_dev"`
+	Ratings []float64 `json:"ratings,omitempty"`
+}
+
+// Spread returns difference between the highest and the lowest raw rating,
+// 0 if entry has no ratings
+func (e LexiconEntry) Spread() float64 {
+	if len(e.Ratings) == 0 {
+		return 0
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, rating := range e.Ratings {
+		min = math.Min(min, rating)
+		max = math.Max(max, rating)
+	}
+
+	return max - min
+}
+
+// copy of entry which doesn't share ratings
+func (e LexiconEntry) clone() LexiconEntry {
+	if e.Ratings != nil {
+		e.Ratings = append([]float64(nil), e.Ratings...)
+	}
+
+	return e
+}
+
+// LookupLexicon returns lexicon entry of a token as it is stored in lexicon,
+// analyzer looks up words in lower case
+func (sia *Analyzer) LookupLexicon(token string) (LexiconEntry, bool) {
+	entry, ok := sia.lexicon[token]
+	if !ok {
+		return LexiconEntry{}, false
+	}
+
+	return entry.clone(), true
+}
+
+// LexiconEntries returns all lexicon entries of the analyzer sorted by token
+func (sia *Analyzer) LexiconEntries() []LexiconEntry {
+	entries := make([]LexiconEntry, 0, len(sia.lexicon))
+	for _, entry := range sia.lexicon {
+		entries = append(entries, entry.clone())
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Token < entries[j].Token
+	})
+
+	return entries
+}
+
+// WithLexiconFilter keeps only lexicon entries for which keep returns true,
+// e.g. drops words raters disagreed about
+func WithLexiconFilter(keep func(entry LexiconEntry) bool) Option {
+	return WithLexiconTransform(func(entry LexiconEntry) (LexiconEntry, bool) {
+		return entry, keep(entry)
+	})
+}
+
+// WithLexiconTransform replaces each lexicon entry with the one returned by transform,
+// entries for which transform returns false are dropped; e.g. weights valence by rater agreement
+func WithLexiconTransform(transform func(entry LexiconEntry) (LexiconEntry, bool)) Option {
+	return edit(func(sia *Analyzer) {
+		// lexicon may be shared with other analyzers, so it is replaced instead of modified
+		lexicon := make(map[string]LexiconEntry, len(sia.lexicon))
+		for _, entry := range sia.lexicon {
+			if entry, ok := transform(entry.clone()); ok {
+				lexicon[entry.Token] = entry
+			}
+		}
+		sia.lexicon = lexicon
+	})
+}
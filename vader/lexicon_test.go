@@ -0,0 +1,78 @@
+package vader
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMakeLexiconEntries(t *testing.T) {
+	lexicon := "good\t1.9\t0.9434\t[2, 1, 2, 3, 2, 2, 1, 2, 3, 1]\nxpositivepercentx\t0.5\t0.4899\nmeh\t-0.3\n"
+
+	entries, skipped, err := MakeLexiconEntries("lexicon", lexicon, Strict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skipped) != 0 {
+		t.Errorf("unexpected skipped lines: %v", skipped)
+	}
+
+	expected := map[string]LexiconEntry{
+		"good":              {Token: "good", Mean: 1.9, StdDev: 0.9434, Ratings: []float64{2, 1, 2, 3, 2, 2, 1, 2, 3, 1}},
+		"xpositivepercentx": {Token: "xpositivepercentx", Mean: 0.5, StdDev: 0.4899},
+		"meh":               {Token: "meh", Mean: -0.3},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+
+	for _, line := range []string{"bad\t-2.5\tx", "bad\t-2.5\t0.5\t[-2, -3", "bad\t-2.5\t0.5\t[-2, x]"} {
+		if _, _, err := MakeLexiconEntries("lexicon", line, Strict); err == nil {
+			t.Errorf("expected error for %q", line)
+		}
+	}
+}
+
+func TestAnalyzer_LookupLexicon(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, ok := sia.LookupLexicon("nah")
+	if !ok {
+		t.Fatal("expected lexicon entry for 'nah'")
+	}
+	if entry.Mean != -0.4 || entry.StdDev != 1.28062 || len(entry.Ratings) != 10 || entry.Spread() != 5 {
+		t.Errorf("unexpected lexicon entry: %+v", entry)
+	}
+
+	// returned entries don't share ratings with analyzer
+	entry.Ratings[0] = 100
+	if entry, _ := sia.LookupLexicon("nah"); entry.Ratings[0] == 100 {
+		t.Error("lexicon entry modified through lookup result")
+	}
+
+	if _, ok := sia.LookupLexicon("vader"); ok {
+		t.Error("unexpected lexicon entry for 'vader'")
+	}
+
+	// drop words raters disagreed about
+	agreed, err := sia.With(WithLexiconFilter(func(entry LexiconEntry) bool {
+		return entry.StdDev < 1
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := agreed.LookupLexicon("nah"); ok {
+		t.Error("expected 'nah' to be filtered out")
+	}
+	if _, ok := sia.LookupLexicon("nah"); !ok {
+		t.Error("filter modified parent analyzer")
+	}
+	if len(agreed.LexiconEntries()) >= len(sia.LexiconEntries()) {
+		t.Error("expected filtered lexicon to be smaller")
+	}
+	if agreed.PolarityScores("nah").Compound != 0 {
+		t.Error("filtered word still scored")
+	}
+}
@@ -0,0 +1,304 @@
+package vader
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+)
+
+// ChangeOp is an operation of lexicon change
+type ChangeOp string
+
+const (
+	ChangeAdd    ChangeOp = "add"
+	ChangeUpdate ChangeOp = "update"
+	ChangeRemove ChangeOp = "remove"
+)
+
+// Change is an edit of valence lexicon or emoji lexicon recorded in history
+type Change struct {
+	// Version of lexicons after the change, initial version is 0,
+	// changes published as a single snapshot, e.g. by Rollback, share the version
+	Version int      `json:"version"`
+	Op      ChangeOp `json:"op"`
+	// Emoji is true for change of emoji lexicon, false for valence lexicon
+	Emoji bool   `json:"emoji"`
+	Token string `json:"token"`
+
+	// valence lexicon entry before and after the change, nil if absent
+	Before *LexiconEntry `json:"before,omitempty"`
+	After  *LexiconEntry `json:"after,omitempty"`
+
+	// lexicon layer of valence lexicon token before and after the change, see LexiconSource,
+	// empty if token is absent or comes from the base lexicon
+	BeforeLayer string `json:"before_layer,omitempty"`
+	AfterLayer  string `json:"after_layer,omitempty"`
+
+	// emoji description before and after the change, empty if absent
+	BeforeDescription string `json:"before_description,omitempty"`
+	AfterDescription  string `json:"after_description,omitempty"`
+
+	Time time.Time `json:"time"`
+}
+
+// LiveAnalyzer is an analyzer which lexicons can be edited at runtime.
+//
+// Every edit builds a new immutable Analyzer snapshot, copying only the edited
+// lexicon, and publishes it atomically. Scoring which already runs keeps its
+// consistent view of the previous snapshot. Edits are recorded in history,
+// so they can be rolled back. LiveAnalyzer is safe for concurrent use.
+type LiveAnalyzer struct {
+	current atomic.Value // *Analyzer
+
+	mu      sync.Mutex // serializes edits
+	version int
+	history []Change
+}
+
+// NewLiveAnalyzer creates live analyzer with sia as initial snapshot of version 0
+func NewLiveAnalyzer(sia *Analyzer) *LiveAnalyzer {
+	live := &LiveAnalyzer{}
+	live.current.Store(sia)
+
+	return live
+}
+
+// Current returns current analyzer snapshot, use it to score
+// several texts with the same lexicons
+func (l *LiveAnalyzer) Current() *Analyzer {
+	return l.current.Load().(*Analyzer)
+}
+
+// Version returns version of current snapshot
+func (l *LiveAnalyzer) Version() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.version
+}
+
+// PolarityScores scores text with current snapshot
+func (l *LiveAnalyzer) PolarityScores(text string) SentimentScores {
+	return l.Current().PolarityScores(text)
+}
+
+// History returns copy of all changes in order they were made
+func (l *LiveAnalyzer) History() []Change {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]Change(nil), l.history...)
+}
+
+// AddWord adds valence lexicon entry, fails if token is already in lexicon.
+// Words are stored in lower case as they are matched, emoticons keep their case, e.g. ":D".
+func (l *LiveAnalyzer) AddWord(entry LexiconEntry) error {
+	return l.editWord(ChangeAdd, entry.Token, &entry)
+}
+
+// UpdateWord replaces valence lexicon entry, fails if token is not in lexicon
+func (l *LiveAnalyzer) UpdateWord(entry LexiconEntry) error {
+	return l.editWord(ChangeUpdate, entry.Token, &entry)
+}
+
+// RemoveWord removes token from valence lexicon, fails if token is not in lexicon
+func (l *LiveAnalyzer) RemoveWord(token string) error {
+	return l.editWord(ChangeRemove, token, nil)
+}
+
+// AddEmoji adds emoji description, fails if emoji is already in emoji lexicon
+func (l *LiveAnalyzer) AddEmoji(emoji, description string) error {
+	return l.editEmoji(ChangeAdd, emoji, description)
+}
+
+// UpdateEmoji replaces emoji description, fails if emoji is not in emoji lexicon
+func (l *LiveAnalyzer) UpdateEmoji(emoji, description string) error {
+	return l.editEmoji(ChangeUpdate, emoji, description)
+}
+
+// RemoveEmoji removes emoji from emoji lexicon, fails if emoji is not in emoji lexicon
+func (l *LiveAnalyzer) RemoveEmoji(emoji string) error {
+	return l.editEmoji(ChangeRemove, emoji, "")
+}
+
+// Rollback restores lexicons of given version by recording inverse
+// of all later changes, which are published as a single snapshot of the next version.
+// Rollback to the current version changes nothing.
+// Rollback itself is recorded in history and can be rolled back as well.
+func (l *LiveAnalyzer) Rollback(version int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if version < 0 || version > l.version {
+		return fmt.Errorf("vader: unknown lexicon version %d", version)
+	}
+
+	var inverse []Change
+	for i := len(l.history) - 1; i >= 0 && l.history[i].Version > version; i-- {
+		inverse = append(inverse, l.history[i].inverse())
+	}
+
+	l.publish(inverse)
+	return nil
+}
+
+func (l *LiveAnalyzer) editWord(op ChangeOp, token string, entry *LexiconEntry) error {
+	if token == "" {
+		return fmt.Errorf("vader: empty lexicon token")
+	}
+	token = lexiconToken(token)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	current := l.Current()
+	change := Change{Op: op, Token: token, BeforeLayer: current.lexiconOrigins[token]}
+	if before, ok := current.lexicon[token]; ok {
+		before = before.clone()
+		change.Before = &before
+	}
+	if err := checkChange(op, token, change.Before != nil); err != nil {
+		return err
+	}
+	if entry != nil {
+		after := entry.clone()
+		after.Token = token
+		change.After = &after
+		change.AfterLayer = LiveLayer
+	}
+
+	l.publish([]Change{change})
+	return nil
+}
+
+func (l *LiveAnalyzer) editEmoji(op ChangeOp, emoji, description string) error {
+	if emoji == "" {
+		return fmt.Errorf("vader: empty emoji")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	before, ok := l.Current().emojiLexicon[emoji]
+	if err := checkChange(op, emoji, ok); err != nil {
+		return err
+	}
+
+	l.publish([]Change{{Op: op, Emoji: true, Token: emoji, BeforeDescription: before, AfterDescription: description}})
+	return nil
+}
+
+// token as it is matched by analyzer: words are lower case,
+// emoticons, i.e. tokens with other punctuation than apostrophes and hyphens, keep their case
+func lexiconToken(token string) string {
+	for _, r := range token {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("'’-", r) {
+			return token
+		}
+	}
+
+	return strings.ToLower(token)
+}
+
+func checkChange(op ChangeOp, token string, exists bool) error {
+	switch {
+	case op == ChangeAdd && exists:
+		return fmt.Errorf("vader: %q is already in lexicon", token)
+	case op != ChangeAdd && !exists:
+		return fmt.Errorf("vader: %q is not in lexicon", token)
+	}
+
+	return nil
+}
+
+// apply changes to a copy of current snapshot, record them and publish the copy
+// as the next version, must be called with mu held
+func (l *LiveAnalyzer) publish(changes []Change) {
+	if len(changes) == 0 {
+		return
+	}
+	l.version++
+
+	current := l.Current()
+	snapshot := *current
+
+	var lexiconCopied, emojiLexiconCopied bool
+	now := time.Now()
+	for _, change := range changes {
+		if change.Emoji {
+			if !emojiLexiconCopied {
+				snapshot.emojiLexicon = make(map[string]string, len(current.emojiLexicon))
+				for emoji, description := range current.emojiLexicon {
+					snapshot.emojiLexicon[emoji] = description
+				}
+				emojiLexiconCopied = true
+			}
+
+			if change.Op == ChangeRemove {
+				delete(snapshot.emojiLexicon, change.Token)
+			} else {
+				snapshot.emojiLexicon[change.Token] = change.AfterDescription
+			}
+		} else {
+			if !lexiconCopied {
+				snapshot.lexicon = make(map[string]LexiconEntry, len(current.lexicon))
+				for token, entry := range current.lexicon {
+					snapshot.lexicon[token] = entry
+				}
+				snapshot.lexiconOrigins = make(map[string]string, len(current.lexiconOrigins)+len(changes))
+				for token, layer := range current.lexiconOrigins {
+					snapshot.lexiconOrigins[token] = layer
+				}
+				lexiconCopied = true
+			}
+
+			if change.Op == ChangeRemove {
+				delete(snapshot.lexicon, change.Token)
+			} else {
+				snapshot.lexicon[change.Token] = change.After.clone()
+			}
+			if change.AfterLayer == "" {
+				delete(snapshot.lexiconOrigins, change.Token)
+			} else {
+				snapshot.lexiconOrigins[change.Token] = change.AfterLayer
+			}
+		}
+
+		change.Version = l.version
+		change.Time = now
+		l.history = append(l.history, change)
+	}
+
+	if emojiLexiconCopied {
+		snapshot.emojiAliases = emojiAliases(snapshot.emojiLexicon)
+	}
+	l.current.Store(&snapshot)
+}
+
+// change which reverts c
+func (c Change) inverse() Change {
+	inverse := Change{
+		Emoji:             c.Emoji,
+		Token:             c.Token,
+		Before:            c.After,
+		After:             c.Before,
+		BeforeLayer:       c.AfterLayer,
+		AfterLayer:        c.BeforeLayer,
+		BeforeDescription: c.AfterDescription,
+		AfterDescription:  c.BeforeDescription,
+	}
+
+	switch c.Op {
+	case ChangeAdd:
+		inverse.Op = ChangeRemove
+	case ChangeRemove:
+		inverse.Op = ChangeAdd
+	default:
+		inverse.Op = ChangeUpdate
+	}
+
+	return inverse
+}
@@ -0,0 +1,176 @@
+package vader
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLiveAnalyzer(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	live := NewLiveAnalyzer(sia)
+
+	sick := live.PolarityScores("That trick was sick").Compound
+	if sick >= 0 {
+		t.Fatalf("expected negative score before edit: %f", sick)
+	}
+
+	if err := live.UpdateWord(LexiconEntry{Token: "sick", Mean: 2.5}); err != nil {
+		t.Fatal(err)
+	}
+	if err := live.AddWord(LexiconEntry{Token: "killer", Mean: 2}); err == nil {
+		t.Error("expected error for adding existing word")
+	}
+	if err := live.UpdateWord(LexiconEntry{Token: "killer", Mean: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := live.AddWord(LexiconEntry{Token: "gpu", Mean: 0.5}); err != nil {
+		t.Fatal(err)
+	}
+	if err := live.RemoveWord("vader"); err == nil {
+		t.Error("expected error for removing missing word")
+	}
+	if err := live.UpdateEmoji("😁", "beaming face with smiling eyes"); err != nil {
+		t.Fatal(err)
+	}
+	if err := live.RemoveEmoji("😁"); err != nil {
+		t.Fatal(err)
+	}
+
+	if live.Version() != 5 || len(live.History()) != 5 {
+		t.Fatalf("expected version 5, got %d: %+v", live.Version(), live.History())
+	}
+	if live.PolarityScores("That trick was sick").Compound <= 0 || live.PolarityScores("killer features").Compound <= 0 {
+		t.Error("edited words not scored")
+	}
+	if _, ok := live.Current().emojiLexicon["😁"]; ok {
+		t.Error("removed emoji still in lexicon")
+	}
+
+	// initial snapshot is not affected
+	if sia.PolarityScores("That trick was sick").Compound != sick {
+		t.Error("initial analyzer modified by edit")
+	}
+
+	// roll back to "sick" update only
+	if err := live.Rollback(1); err != nil {
+		t.Fatal(err)
+	}
+	if live.Version() != 6 || len(live.History()) != 9 {
+		t.Fatalf("expected version 6 with 9 changes, got %d: %+v", live.Version(), live.History())
+	}
+	for _, change := range live.History()[5:] {
+		if change.Version != 6 {
+			t.Errorf("rollback change of version %d, expected 6", change.Version)
+		}
+	}
+	if live.PolarityScores("That trick was sick").Compound <= 0 {
+		t.Error("rollback reverted too much")
+	}
+	if live.PolarityScores("killer features") != sia.PolarityScores("killer features") {
+		t.Error("rollback didn't revert 'killer' update")
+	}
+	if _, ok := live.Current().LookupLexicon("gpu"); ok {
+		t.Error("rollback didn't revert added word")
+	}
+	if description := live.Current().emojiLexicon["😁"]; description != sia.emojiLexicon["😁"] {
+		t.Errorf("rollback didn't revert emoji changes: %q", description)
+	}
+
+	// rollback is recorded in history and can be rolled back too
+	if err := live.Rollback(5); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := live.Current().LookupLexicon("gpu"); !ok {
+		t.Error("rollback of rollback didn't restore added word")
+	}
+	if live.Version() != 7 {
+		t.Errorf("expected version 7, got %d", live.Version())
+	}
+	if err := live.Rollback(7); err != nil || live.Version() != 7 {
+		t.Errorf("rollback to current version changed version to %d: %v", live.Version(), err)
+	}
+	if err := live.Rollback(100); err == nil {
+		t.Error("expected error for unknown version")
+	}
+}
+
+func TestLiveAnalyzer_TokenCase(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	live := NewLiveAnalyzer(sia)
+
+	if err := live.AddWord(LexiconEntry{Token: "Yeet", Mean: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if entry, ok := live.Current().LookupLexicon("yeet"); !ok || entry.Token != "yeet" {
+		t.Errorf("word is not stored in lower case: %+v", entry)
+	}
+	if live.PolarityScores("Yeet it") == sia.PolarityScores("Yeet it") {
+		t.Error("added word is not scored")
+	}
+	if err := live.AddWord(LexiconEntry{Token: "Don't-Care", Mean: -1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := live.Current().LookupLexicon("don't-care"); !ok {
+		t.Error("word with apostrophe and hyphen is not stored in lower case")
+	}
+	if err := live.RemoveWord("SICK"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := live.Current().LookupLexicon("sick"); ok {
+		t.Error("word removed in upper case is still in lexicon")
+	}
+
+	// emoticons keep their case
+	if err := live.AddWord(LexiconEntry{Token: ":O)", Mean: 1.5}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := live.Current().LookupLexicon(":O)"); !ok {
+		t.Error("emoticon is not stored in its case")
+	}
+}
+
+// run with -race to detect data races
+func TestLiveAnalyzer_Concurrent(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	live := NewLiveAnalyzer(sia)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 50; i++ {
+				// snapshot gives consistent view for both texts
+				snapshot := live.Current()
+				if snapshot.PolarityScores("sick") != snapshot.PolarityScores("sick") {
+					t.Error("inconsistent snapshot")
+				}
+				live.PolarityScores("killer features")
+			}
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		if err := live.UpdateWord(LexiconEntry{Token: "sick", Mean: float64(i%8 - 4)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := live.Rollback(0); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	if live.PolarityScores("sick") != sia.PolarityScores("sick") {
+		t.Error("rollback to initial version didn't restore lexicon")
+	}
+}
@@ -0,0 +1,208 @@
+package vader
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+)
+
+// Option configures analyzer built by New or derived by With
+type Option func(b *builder) error
+
+// configuration collected from options
+type builder struct {
+	options   *Options
+	parseMode ParseMode
+	lexicons  *lexiconSource
+	state     *LexiconState
+	language  *LanguagePack
+	edits     []func(sia *Analyzer)
+}
+
+// named content of valence lexicon and emoji lexicon
+type lexiconSource struct {
+	lexiconName      string
+	lexicon          string
+	emojiLexiconName string
+	emojiLexicon     string
+}
+
+// option which modifies analyzer being built, after lexicons are loaded
+func edit(f func(sia *Analyzer)) Option {
+	return func(b *builder) error {
+		b.edits = append(b.edits, f)
+		return nil
+	}
+}
+
+// WithOptions sets heuristic constants, which are validated when analyzer is built
+func WithOptions(options Options) Option {
+	return func(b *builder) error {
+		b.options = &options
+		return nil
+	}
+}
+
+// WithParseMode sets parse mode of lexicons loaded by options, Strict by default
+func WithParseMode(mode ParseMode) Option {
+	return func(b *builder) error {
+		b.parseMode = mode
+		return nil
+	}
+}
+
+// WithLexiconFiles loads valence lexicon and emoji lexicon from files
+func WithLexiconFiles(lexiconFilename, emojiLexiconFilename string) Option {
+	return func(b *builder) error {
+		lexicon, err := ioutil.ReadFile(lexiconFilename)
+		if err != nil {
+			return err
+		}
+
+		emojiLexicon, err := ioutil.ReadFile(emojiLexiconFilename)
+		if err != nil {
+			return err
+		}
+
+		b.lexicons = &lexiconSource{lexiconFilename, string(lexicon), emojiLexiconFilename, string(emojiLexicon)}
+		return nil
+	}
+}
+
+// WithLexiconReaders loads valence lexicon and emoji lexicon from readers
+func WithLexiconReaders(lexiconReader, emojiLexiconReader io.Reader) Option {
+	return func(b *builder) error {
+		if lexiconReader == nil || emojiLexiconReader == nil {
+			return errors.New("vader: both valence and emoji lexicon readers are required")
+		}
+
+		lexicon, err := ioutil.ReadAll(lexiconReader)
+		if err != nil {
+			return err
+		}
+
+		emojiLexicon, err := ioutil.ReadAll(emojiLexiconReader)
+		if err != nil {
+			return err
+		}
+
+		b.lexicons = &lexiconSource{readerName(lexiconReader, "lexicon"), string(lexicon), readerName(emojiLexiconReader, "emoji lexicon"), string(emojiLexicon)}
+		return nil
+	}
+}
+
+// WithLexiconFS loads valence lexicon and emoji lexicon stored in fsys under given names,
+// e.g. embedded filesystem, config bundle or test fixtures
+func WithLexiconFS(fsys fs.FS, lexiconName, emojiLexiconName string) Option {
+	return func(b *builder) error {
+		lexicon, err := fs.ReadFile(fsys, lexiconName)
+		if err != nil {
+			return err
+		}
+
+		emojiLexicon, err := fs.ReadFile(fsys, emojiLexiconName)
+		if err != nil {
+			return err
+		}
+
+		b.lexicons = &lexiconSource{lexiconName, string(lexicon), emojiLexiconName, string(emojiLexicon)}
+		return nil
+	}
+}
+
+// Options are heuristic constants of sentiment analyzer,
+// DefaultOptions returns values used by original VADER.
+type Options struct {
+	BIncr   float64 // rating increase for booster words
+	BDecr   float64 // rating decrease for dampener words
+	CIncr   float64 // rating increase for using ALLCAPS to emphasize a word
+	NScalar float64 // scalar applied to negated valence
+
+	Alpha     float64 // constant for normalize
+	IncludeNt bool    // flag to check "n't" in negated
+
+	MaxEM  int     // max number of exclamation points taken into account
+	EPIncr float64 // rating increase for each exclamation point
+	MaxQM  int     // max number of question marks scored per mark
+	QMIncr float64 // rating increase for each question mark (2 up to MaxQM)
+	QMMax  float64 // rating increase for more than MaxQM question marks
+
+	ButBefore float64 // scalar for valence of words before "but"
+	ButAfter  float64 // scalar for valence of words after "but"
+
+	// scalars for booster words 2 and 3 words before the sentiment word
+	BoosterDecay [2]float64
+}
+
+// DefaultOptions returns empirically derived constants of original VADER
+func DefaultOptions() Options {
+	return Options{
+		BIncr:        B_INCR,
+		BDecr:        B_DECR,
+		CIncr:        C_INCR,
+		NScalar:      N_SCALAR,
+		Alpha:        Alpha,
+		IncludeNt:    IncludeNt,
+		MaxEM:        MaxEM,
+		EPIncr:       0.292,
+		MaxQM:        MaxQM,
+		QMIncr:       0.18,
+		QMMax:        0.96,
+		ButBefore:    0.5,
+		ButAfter:     1.5,
+		BoosterDecay: [2]float64{0.95, 0.9},
+	}
+}
+
+// Validate returns error describing the first invalid option
+func (o Options) Validate() error {
+	switch {
+	case !(o.BIncr > 0):
+		return invalidOption("BIncr", o.BIncr, "must be positive")
+	case !(o.BDecr < 0):
+		return invalidOption("BDecr", o.BDecr, "must be negative")
+	case !(o.CIncr >= 0):
+		return invalidOption("CIncr", o.CIncr, "must not be negative")
+	case !(o.NScalar < 0):
+		return invalidOption("NScalar", o.NScalar, "must be negative")
+	case !(o.Alpha > 0):
+		return invalidOption("Alpha", o.Alpha, "must be positive")
+	case o.MaxEM < 0:
+		return invalidOption("MaxEM", o.MaxEM, "must not be negative")
+	case !(o.EPIncr >= 0):
+		return invalidOption("EPIncr", o.EPIncr, "must not be negative")
+	case o.MaxQM < 1:
+		return invalidOption("MaxQM", o.MaxQM, "must be at least 1")
+	case !(o.QMIncr >= 0):
+		return invalidOption("QMIncr", o.QMIncr, "must not be negative")
+	case !(o.QMMax >= 0):
+		return invalidOption("QMMax", o.QMMax, "must not be negative")
+	case !(o.ButBefore >= 0):
+		return invalidOption("ButBefore", o.ButBefore, "must not be negative")
+	case !(o.ButAfter >= 0):
+		return invalidOption("ButAfter", o.ButAfter, "must not be negative")
+	}
+
+	for i, decay := range o.BoosterDecay {
+		if !(decay >= 0 && decay <= 1) {
+			return invalidOption(fmt.Sprintf("BoosterDecay[%d]", i), decay, "must be between 0 and 1")
+		}
+	}
+
+	return nil
+}
+
+func invalidOption(name string, value interface{}, reason string) error {
+	return fmt.Errorf("vader: invalid option %s = %v: %s", name, value, reason)
+}
+
+// name of the reader if it has one (e.g. *os.File), fallback otherwise
+func readerName(r io.Reader, fallback string) string {
+	if named, ok := r.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+
+	return fallback
+}
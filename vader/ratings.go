@@ -0,0 +1,230 @@
+package vader
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// lexicon layer of rating placeholders
+const ratingsLayer = "ratings"
+
+// number of rating placeholders is ratingSteps+1, from xrating0x for the worst rating to xrating10x
+const ratingSteps = 10
+
+// Match ratings: fractions such as "4/5", phrases such as "9 out of 10", runs of star glyphs,
+// runs of thumbs emoji such as "👍👍👎" and thumbs phrases such as "two thumbs up"
+var (
+	FractionRatingRegexp     = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*/\s*(\d+)`)
+	OutOfRatingRegexp        = regexp.MustCompile(`(?i)(\d+(?:[.,]\d+)?)\s+out\s+of\s+(\d+)`)
+	StarRatingRegexp         = regexp.MustCompile(`[★☆]{2,}`)
+	ThumbsRatingRegexp       = regexp.MustCompile(`(?:[👍👎][\x{1F3FB}-\x{1F3FF}]?\x{FE0F}?)+`)
+	ThumbsPhraseRatingRegexp = regexp.MustCompile(`(?i)\b(?:(?:two|2|both)\s+)?thumbs?[\s-]+(up|down)\b`)
+)
+
+// RatingRecognizer finds explicit ratings in text, such as "4/5", "9 out of 10", "★★★★☆"
+// or "👍", and replaces them with placeholders which valences are scaled by the rating.
+// Fractions after counting words, e.g. "page 3/5", and single star glyphs, e.g. bullets, are not ratings.
+type RatingRecognizer struct {
+	// Scales are accepted best ratings of fractions and "x out of y" phrases,
+	// so that e.g. "24/7" is not a rating
+	Scales []int
+	// Fractions, OutOf and Stars enable recognition of "x/y", "x out of y" and star glyphs
+	Fractions bool
+	OutOf     bool
+	Stars     bool
+	// Thumbs enables recognition of thumbs emoji and "thumbs up/down" phrases,
+	// which are rated by share of thumbs up, e.g. "👍👍👎" as 2 out of 3
+	Thumbs bool
+	// MaxValence is valence of the best rating, the worst rating has -MaxValence
+	// and the middle one is neutral
+	MaxValence float64
+}
+
+// NewRatingRecognizer creates recognizer of all rating forms with scales 5 and 10,
+// scale 100 is not included as it mostly matches page counts and progress, e.g. "15/100"
+func NewRatingRecognizer() *RatingRecognizer {
+	return &RatingRecognizer{
+		Scales:     []int{5, 10},
+		Fractions:  true,
+		OutOf:      true,
+		Stars:      true,
+		Thumbs:     true,
+		MaxValence: 3,
+	}
+}
+
+// WithRatings adds rating recognizer after recognizers of the analyzer,
+// rating placeholders are stacked over lexicon as "ratings" layer
+func WithRatings(recognizer *RatingRecognizer) Option {
+	return func(b *builder) error {
+		if recognizer == nil {
+			return errors.New("vader: nil rating recognizer")
+		}
+		if err := recognizer.validate(); err != nil {
+			return err
+		}
+
+		// recognizer is copied, so that it is not modified through caller's pointer
+		r := recognizer.clone()
+
+		b.edits = append(b.edits, func(sia *Analyzer) {
+			sia.recognizers = append(append(Recognizers(nil), sia.recognizers...), r)
+			sia.stackPlaceholders(ratingsLayer, r.Placeholders())
+		})
+		return nil
+	}
+}
+
+// copy of recognizer which doesn't share scales
+func (r *RatingRecognizer) clone() *RatingRecognizer {
+	clone := *r
+	clone.Scales = append([]int(nil), r.Scales...)
+
+	return &clone
+}
+
+func (r *RatingRecognizer) validate() error {
+	if !(r.MaxValence > 0) {
+		return fmt.Errorf("vader: invalid rating max valence %v", r.MaxValence)
+	}
+	for _, scale := range r.Scales {
+		if scale <= 0 {
+			return fmt.Errorf("vader: invalid rating scale %d", scale)
+		}
+	}
+
+	return nil
+}
+
+// Placeholders returns lexicon entries of rating placeholders
+func (r *RatingRecognizer) Placeholders() []LexiconEntry {
+	entries := make([]LexiconEntry, 0, ratingSteps+1)
+	for step := 0; step <= ratingSteps; step++ {
+		entries = append(entries, LexiconEntry{
+			Token: ratingPlaceholder(step),
+			Mean:  float64(2*step-ratingSteps) / ratingSteps * r.MaxValence,
+		})
+	}
+
+	return entries
+}
+
+func ratingPlaceholder(step int) string {
+	return fmt.Sprintf("xrating%dx", step)
+}
+
+// Replace replaces ratings in text with placeholders
+func (r *RatingRecognizer) Replace(text string) string {
+	if r.OutOf {
+		text = r.replaceFractions(text, OutOfRatingRegexp)
+	}
+	if r.Fractions && strings.Contains(text, "/") {
+		text = r.replaceFractions(text, FractionRatingRegexp)
+	}
+	if r.Stars {
+		text = StarRatingRegexp.ReplaceAllStringFunc(text, func(stars string) string {
+			filled := strings.Count(stars, "★")
+			best := utf8.RuneCountInString(stars)
+			// filled stars alone are rated out of 5
+			if filled == best && best < 5 {
+				best = 5
+			}
+			return " " + ratingPlaceholder(ratingStep(float64(filled), float64(best))) + " "
+		})
+	}
+	if r.Thumbs {
+		if strings.ContainsAny(text, "👍👎") {
+			text = ThumbsRatingRegexp.ReplaceAllStringFunc(text, func(thumbs string) string {
+				up := strings.Count(thumbs, "👍")
+				return " " + ratingPlaceholder(ratingStep(float64(up), float64(up+strings.Count(thumbs, "👎")))) + " "
+			})
+		}
+		text = ThumbsPhraseRatingRegexp.ReplaceAllStringFunc(text, func(phrase string) string {
+			step := ratingSteps
+			if strings.HasSuffix(strings.ToLower(phrase), "down") {
+				step = 0
+			}
+			return " " + ratingPlaceholder(step) + " "
+		})
+	}
+
+	return text
+}
+
+// replace ratings matched by regexp with rating and best rating submatches
+func (r *RatingRecognizer) replaceFractions(text string, re *regexp.Regexp) string {
+	var b strings.Builder
+
+	last := 0
+	for _, match := range re.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[0], match[1]
+		rating, err := strconv.ParseFloat(strings.Replace(text[match[2]:match[3]], ",", ".", 1), 64)
+		if err != nil {
+			continue
+		}
+		best, err := strconv.Atoi(text[match[4]:match[5]])
+		if err != nil || !r.isScale(best) || rating > float64(best) || !isStandaloneNumber(text, start, end) ||
+			followsCountingWord(text[:start]) {
+			continue
+		}
+
+		b.WriteString(text[last:start])
+		b.WriteString(" " + ratingPlaceholder(ratingStep(rating, float64(best))) + " ")
+		last = end
+	}
+	if last == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+
+	return b.String()
+}
+
+func (r *RatingRecognizer) isScale(best int) bool {
+	for _, scale := range r.Scales {
+		if scale == best {
+			return true
+		}
+	}
+
+	return false
+}
+
+// check whether text[start:end] is not part of a longer number, date or path, e.g. "12/05/2020"
+func isStandaloneNumber(text string, start, end int) bool {
+	if start > 0 && strings.ContainsRune("0123456789/.,", rune(text[start-1])) {
+		return false
+	}
+	if end < len(text) && strings.ContainsRune("0123456789/", rune(text[end])) {
+		return false
+	}
+
+	return true
+}
+
+// words which count parts of something rather than rate it, e.g. "page 3/5"
+var countingWords = map[string]bool{
+	"page": true, "pages": true, "p": true, "pp": true, "step": true, "steps": true, "part": true, "parts": true,
+	"chapter": true, "ch": true, "episode": true, "ep": true, "slide": true, "question": true, "q": true,
+	"day": true, "week": true, "round": true, "game": true, "set": true, "track": true, "disc": true,
+	"vol": true, "volume": true, "no": true, "item": true, "level": true, "stage": true, "lesson": true,
+}
+
+// check whether text before a fraction ends with a counting word
+func followsCountingWord(before string) bool {
+	fields := strings.Fields(before)
+	if len(fields) == 0 {
+		return false
+	}
+
+	return countingWords[strings.ToLower(strings.TrimFunc(fields[len(fields)-1], isPunctuation))]
+}
+
+// step of rating placeholder for rating out of best rating
+func ratingStep(rating, best float64) int {
+	return int(rating/best*ratingSteps + 0.5)
+}
@@ -0,0 +1,110 @@
+package vader
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRatingRecognizer_Replace(t *testing.T) {
+	recognizer := NewRatingRecognizer()
+
+	tests := map[string]string{
+		"2/10 would not buy":      "xrating2x would not buy",
+		"Rated 4.5/5.":            "Rated xrating9x .",
+		"I give it 4,5 / 5":       "I give it xrating9x",
+		"9 out of 10 dentists":    "xrating9x dentists",
+		"Solid 7 Out Of 10!":      "Solid xrating7x !",
+		"★★★★☆":                   "xrating8x",
+		"★★★★★ service":           "xrating10x service",
+		"★★★":                     "xrating6x",
+		"☆☆☆☆☆":                   "xrating0x",
+		"Open 24/7":               "Open 24/7",
+		"Since 12/05/2020":        "Since 12/05/2020",
+		"1/2 price":               "1/2 price",
+		"11/10 would buy again":   "11/10 would buy again",
+		"Pages 15/100 of a book.": "Pages 15/100 of a book.",
+		"👍👍👍":                     "xrating10x",
+		"Food 👍🏽👍🏽👎":              "Food xrating7x",
+		"👎 never again":           "xrating0x never again",
+		"Two thumbs up!":          "xrating10x !",
+		"Service: thumbs-down":    "Service: xrating0x",
+		"★ New arrivals":          "★ New arrivals",
+		"★ Fast ★ Cheap":          "★ Fast ★ Cheap",
+		"★★ meh":                  "xrating4x meh",
+		"Page 3/5 of the report":  "Page 3/5 of the report",
+		"See p. 4/5, step 2/5":    "See p. 4/5, step 2/5",
+		"Story 4/5, page 1/5":     "Story xrating8x , page 1/5",
+	}
+
+	for text, expected := range tests {
+		if replaced := strings.Join(strings.Fields(recognizer.Replace(text)), " "); replaced != expected {
+			t.Errorf("unexpected replacement of %q: %q, expected %q", text, replaced, expected)
+		}
+	}
+
+	percent := NewRatingRecognizer()
+	percent.Scales = append(percent.Scales, 100)
+	if replaced := strings.Join(strings.Fields(percent.Replace("Scored 85/100")), " "); replaced != "Scored xrating9x" {
+		t.Errorf("unexpected replacement with scale 100: %q", replaced)
+	}
+
+	stars := &RatingRecognizer{Stars: true, MaxValence: 3}
+	if replaced := stars.Replace("4/5 and ★★"); !strings.HasPrefix(replaced, "4/5 and ") {
+		t.Errorf("disabled fraction rating replaced: %q", replaced)
+	}
+	if replaced := stars.Replace("thumbs up 👍"); replaced != "thumbs up 👍" {
+		t.Errorf("disabled thumbs rating replaced: %q", replaced)
+	}
+}
+
+func TestWithRatings(t *testing.T) {
+	plain, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sia, err := plain.With(WithRatings(NewRatingRecognizer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plain.PolarityScores("2/10").Compound != 0 {
+		t.Error("rating recognized without recognizer")
+	}
+
+	score := func(text string) float64 {
+		return sia.PolarityScores(text).Compound
+	}
+	if score("2/10 would buy") >= 0 || score("★★★★★") <= 0 || score("Service was 9 out of 10") <= 0 {
+		t.Error("unexpected polarity of ratings")
+	}
+	if score("👍👍👍") <= 0 || score("thumbs down") >= 0 {
+		t.Error("unexpected polarity of thumbs")
+	}
+	if score("5/10") != 0 {
+		t.Error("middle rating is not neutral")
+	}
+	for _, text := range []string{"★ New arrivals", "Page 3/5 of the report"} {
+		if sia.PolarityScores(text) != plain.PolarityScores(text) {
+			t.Errorf("%q is scored as rating", text)
+		}
+	}
+	if score("★★★★★") <= score("★★★★☆") {
+		t.Error("better rating has lower score")
+	}
+	if score("Not 5/5") >= 0 {
+		t.Error("negated rating is not negative")
+	}
+
+	if entry, ok := sia.LookupLexicon("xrating10x"); !ok || entry.Mean != 3 {
+		t.Errorf("unexpected placeholder entry: %+v", entry)
+	}
+	if layer, _ := sia.LexiconSource("xrating0x"); layer != "ratings" {
+		t.Errorf("unexpected placeholder layer: %q", layer)
+	}
+
+	for _, recognizer := range []*RatingRecognizer{nil, {}, {MaxValence: 3, Scales: []int{0}}} {
+		if _, err := New(WithRatings(recognizer)); err == nil {
+			t.Errorf("expected error for recognizer %+v", recognizer)
+		}
+	}
+}
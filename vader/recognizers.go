@@ -0,0 +1,307 @@
+package vader
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// lexicon layer of placeholders of recognizers given to WithRecognizers
+const recognizersLayer = "recognizers"
+
+// Recognizer replaces patterns of text, such as percentages or ratings, with placeholder tokens
+// before the text is tokenized. Placeholders are lexicon entries of the tokens it produces.
+type Recognizer interface {
+	Replace(text string) string
+	Placeholders() []LexiconEntry
+}
+
+// PatternRecognizer replaces matches of Pattern with Placeholder token of Valence
+type PatternRecognizer struct {
+	Pattern     *regexp.Regexp
+	Placeholder string
+	Valence     float64
+	// Requires, if not empty, skips matching of texts which contain none of its characters
+	Requires string
+	// Boundary, if not empty, skips matches followed by a digit or by one of its characters
+	// and a digit, so that a number is not matched partially, e.g. "$4" of "$4.50" if "."
+	// is not decimal separator
+	Boundary string
+}
+
+// Replace replaces matches of pattern in text with placeholder
+func (r *PatternRecognizer) Replace(text string) string {
+	if r.Requires != "" && !strings.ContainsAny(text, r.Requires) {
+		return text
+	}
+	if r.Boundary == "" {
+		return r.Pattern.ReplaceAllLiteralString(text, " "+r.Placeholder+" ")
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, match := range r.Pattern.FindAllStringIndex(text, -1) {
+		if !r.endsAtBoundary(text[match[1]:]) {
+			continue
+		}
+		b.WriteString(text[last:match[0]])
+		b.WriteString(" " + r.Placeholder + " ")
+		last = match[1]
+	}
+	if b.Len() == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+
+	return b.String()
+}
+
+// check whether match followed by rest of text ends at boundary, i.e. rest doesn't start
+// with a digit or with one of boundary characters followed by a digit
+func (r *PatternRecognizer) endsAtBoundary(rest string) bool {
+	next, size := utf8.DecodeRuneInString(rest)
+	if unicode.IsDigit(next) {
+		return false
+	}
+	if strings.ContainsRune(r.Boundary, next) {
+		after, _ := utf8.DecodeRuneInString(rest[size:])
+		return !unicode.IsDigit(after)
+	}
+
+	return true
+}
+
+// Placeholders returns lexicon entry of the placeholder
+func (r *PatternRecognizer) Placeholders() []LexiconEntry {
+	return []LexiconEntry{{Token: r.Placeholder, Mean: r.Valence}}
+}
+
+// Recognizers is an ordered registry of recognizers, each recognizer
+// is applied to text replaced by recognizers before it
+type Recognizers []Recognizer
+
+// Replace applies all recognizers to text in order
+func (rs Recognizers) Replace(text string) string {
+	for _, r := range rs {
+		text = r.Replace(text)
+	}
+
+	return text
+}
+
+// Placeholders returns placeholders of all recognizers
+func (rs Recognizers) Placeholders() []LexiconEntry {
+	var entries []LexiconEntry
+	for _, r := range rs {
+		entries = append(entries, r.Placeholders()...)
+	}
+
+	return entries
+}
+
+// SetValence sets valence of placeholder of pattern recognizers in the registry,
+// returns false if no recognizer produces the placeholder
+func (rs Recognizers) SetValence(placeholder string, valence float64) bool {
+	found := false
+	for _, r := range rs {
+		switch r := r.(type) {
+		case *PatternRecognizer:
+			if r.Placeholder == placeholder {
+				r.Valence = valence
+				found = true
+			}
+		case Recognizers:
+			found = r.SetValence(placeholder, valence) || found
+		}
+	}
+
+	return found
+}
+
+// registry of analyzers built without WithRecognizers, it is never modified
+var defaultRecognizers = DefaultRecognizers()
+
+// DefaultRecognizers returns recognizers of signed percentages used by default,
+// matched by PositivePercentageRegexp and NegativePercentageRegexp
+func DefaultRecognizers() Recognizers {
+	return Recognizers{
+		&PatternRecognizer{Pattern: PositivePercentageRegexp, Placeholder: "xpositivepercentx", Valence: 0.5, Requires: "%"},
+		&PatternRecognizer{Pattern: NegativePercentageRegexp, Placeholder: "xnegativepercentx", Valence: -0.5, Requires: "%"},
+	}
+}
+
+// NumberFormat describes how numbers are written in a locale
+type NumberFormat struct {
+	// DecimalSeparators are accepted decimal separators, either "." or "," if empty
+	DecimalSeparators string
+	// GroupSeparators are accepted separators of thousands, numbers are not grouped if empty
+	GroupSeparators string
+}
+
+// Number formats with decimal point, e.g. "1,234.5", and with decimal comma, e.g. "1.234,5" or "1 234,5",
+// which groups may be separated by space or no-break space
+var (
+	PointDecimalFormat = NumberFormat{DecimalSeparators: ".", GroupSeparators: ","}
+	CommaDecimalFormat = NumberFormat{DecimalSeparators: ",", GroupSeparators: ". \u00A0\u202F"}
+)
+
+// regular expression of unsigned numbers of the format
+func (f NumberFormat) pattern() string {
+	decimal := f.DecimalSeparators
+	if decimal == "" {
+		decimal = ".,"
+	}
+
+	digits := `\d+`
+	if f.GroupSeparators != "" {
+		digits = `\d{1,3}(?:[` + quoteClass(f.GroupSeparators) + `]\d{3})+|\d+`
+	}
+
+	return `(?:` + digits + `)(?:[` + quoteClass(decimal) + `]\d+)?`
+}
+
+// separators of numbers of the format, boundary of recognizers which end with a number
+func (f NumberFormat) separators() string {
+	if f.DecimalSeparators == "" {
+		return ".," + f.GroupSeparators
+	}
+
+	return f.DecimalSeparators + f.GroupSeparators
+}
+
+// escape characters to be used in regular expression character class
+func quoteClass(chars string) string {
+	var b strings.Builder
+	for _, r := range chars {
+		if strings.ContainsRune(`\-]^[`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// PercentageRecognizers returns recognizers of signed percentages, e.g. "+2%" or "-2,5 %",
+// for numbers of the format
+func PercentageRecognizers(format NumberFormat) Recognizers {
+	number := format.pattern()
+
+	return Recognizers{
+		newPatternRecognizer(`(?:\(|\s)*\+`+number+`\s?%(?:\)|\s)*`, "xpositivepercentx", 0.5, "%"),
+		newPatternRecognizer(`(?:\(|\s)*[-−]`+number+`\s?%(?:\)|\s)*`, "xnegativepercentx", -0.5, "%"),
+	}
+}
+
+// Placeholders of finance recognizers
+const (
+	FinanceArrowUp         = "xfinancearrowupx"
+	FinanceArrowDown       = "xfinancearrowdownx"
+	FinanceBasisPointsUp   = "xfinancebpupx"
+	FinanceBasisPointsDown = "xfinancebpdownx"
+	FinanceMoneyUp         = "xfinancemoneyupx"
+	FinanceMoneyDown       = "xfinancemoneydownx"
+	FinancePointsUp        = "xfinancepointsupx"
+	FinancePointsDown      = "xfinancepointsdownx"
+)
+
+// FinanceRecognizers returns preset of recognizers of market commentary for numbers of the format:
+// arrows optionally followed by a change, e.g. "▲1.2" or "↓", signed basis points, e.g. "+25bp",
+// money changes, e.g. "down $4.50" or "+€3", point changes, e.g. "up 3 points", and signed percentages.
+// Placeholders have valence 0.5 for rises and -0.5 for falls, which can be changed with SetValence.
+// Numbers which are not of the format, e.g. "$4.50" of CommaDecimalFormat, are not recognized.
+func FinanceRecognizers(format NumberFormat) Recognizers {
+	number, boundary := format.pattern(), format.separators()
+	const (
+		up       = `[▲△⬆↑↗📈]\x{FE0F}?`
+		down     = `[▼▽⬇↓↘📉]\x{FE0F}?`
+		currency = `[$€£¥]`
+	)
+
+	recognizers := Recognizers{
+		newNumberRecognizer(up+`(?:\s*\+?`+number+`\s?%?)?`, FinanceArrowUp, 0.5, "▲△⬆↑↗📈", boundary),
+		newNumberRecognizer(down+`(?:\s*[-−]?`+number+`\s?%?)?`, FinanceArrowDown, -0.5, "▼▽⬇↓↘📉", boundary),
+		newPatternRecognizer(`(?i)\+\s?`+number+`\s?(?:bps?|basis\s+points?)\b`, FinanceBasisPointsUp, 0.5, "+"),
+		newPatternRecognizer(`(?i)[-−]\s?`+number+`\s?(?:bps?|basis\s+points?)\b`, FinanceBasisPointsDown, -0.5, "-−"),
+		newNumberRecognizer(`(?i)(?:\b(?:up|gain(?:ed|s)?|rose|rises?|climb(?:ed|s)?)\s+|\+\s?)`+currency+`\s?`+number, FinanceMoneyUp, 0.5, "$€£¥", boundary),
+		newNumberRecognizer(`(?i)(?:\b(?:down|los(?:t|es?)|fell|falls?|drop(?:ped|s)?)\s+|[-−]\s?)`+currency+`\s?`+number, FinanceMoneyDown, -0.5, "$€£¥", boundary),
+		newPatternRecognizer(`(?i)\b(?:up|gain(?:ed|s)?|rose|rises?|climb(?:ed|s)?|add(?:ed|s)?)\s+`+number+`\s+(?:points?|pts?)\b`, FinancePointsUp, 0.5, ""),
+		newPatternRecognizer(`(?i)\b(?:down|los(?:t|es?)|fell|falls?|drop(?:ped|s)?|shed)\s+`+number+`\s+(?:points?|pts?)\b`, FinancePointsDown, -0.5, ""),
+	}
+
+	return append(recognizers, PercentageRecognizers(format)...)
+}
+
+func newPatternRecognizer(pattern, placeholder string, valence float64, requires string) *PatternRecognizer {
+	return &PatternRecognizer{Pattern: regexp.MustCompile(pattern), Placeholder: placeholder, Valence: valence, Requires: requires}
+}
+
+// recognizer of pattern which ends with a number, its matches must end at boundary of the number
+func newNumberRecognizer(pattern, placeholder string, valence float64, requires, boundary string) *PatternRecognizer {
+	r := newPatternRecognizer(pattern, placeholder, valence, requires)
+	r.Boundary = boundary
+
+	return r
+}
+
+// WithRecognizers replaces recognizers of the analyzer, including the default percentage
+// recognizers, which can be kept by passing DefaultRecognizers too. Placeholders which are
+// missing in the lexicon or have different valence are stacked over it as "recognizers" layer.
+// Pattern and rating recognizers, also nested in Recognizers, are copied, so that they are
+// not modified through caller's pointers; other recognizers must not be modified after
+// the analyzer is built.
+func WithRecognizers(recognizers ...Recognizer) Option {
+	return func(b *builder) error {
+		for _, r := range recognizers {
+			if r == nil {
+				return errors.New("vader: nil recognizer")
+			}
+			if pr, ok := r.(*PatternRecognizer); ok && (pr.Pattern == nil || pr.Placeholder == "") {
+				return errors.New("vader: pattern recognizer without pattern or placeholder")
+			}
+		}
+
+		rs := copyRecognizer(Recognizers(recognizers)).(Recognizers)
+		b.edits = append(b.edits, func(sia *Analyzer) {
+			sia.recognizers = rs
+			sia.stackPlaceholders(recognizersLayer, rs.Placeholders())
+		})
+		return nil
+	}
+}
+
+// copy of pattern and rating recognizers, recognizers of other types are kept as they are
+func copyRecognizer(r Recognizer) Recognizer {
+	switch r := r.(type) {
+	case *PatternRecognizer:
+		clone := *r
+		return &clone
+	case *RatingRecognizer:
+		return r.clone()
+	case Recognizers:
+		clone := make(Recognizers, len(r))
+		for i, nested := range r {
+			clone[i] = copyRecognizer(nested)
+		}
+		return clone
+	}
+
+	return r
+}
+
+// stack placeholders which are not in lexicon with the same valence as a layer
+func (sia *Analyzer) stackPlaceholders(name string, placeholders []LexiconEntry) {
+	var entries []LexiconEntry
+	for _, entry := range placeholders {
+		if previous, ok := sia.lexicon[entry.Token]; ok && previous.Mean == entry.Mean {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) > 0 {
+		sia.stackLayers(LexiconLayer{Name: name, Entries: entries})
+	}
+}
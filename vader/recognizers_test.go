@@ -0,0 +1,146 @@
+package vader
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultRecognizers(t *testing.T) {
+	recognizers := DefaultRecognizers()
+
+	for _, text := range []string{"Shares (+2%) today", "Shares -2,5 % today", "Shares 2% today", "Shares +25bp today"} {
+		if replaced, expected := recognizers.Replace(text), ReplacePercentages(text); replaced != expected {
+			t.Errorf("unexpected replacement of %q: %q, expected %q", text, replaced, expected)
+		}
+	}
+}
+
+func TestFinanceRecognizers(t *testing.T) {
+	recognizers := FinanceRecognizers(PointDecimalFormat)
+
+	tests := map[string]string{
+		"Dow up 3 points":            "Dow xfinancepointsupx",
+		"Nasdaq fell 120.5 pts":      "Nasdaq xfinancepointsdownx",
+		"AAPL ▲1.2":                  "AAPL xfinancearrowupx",
+		"AAPL ▼ -0.8%":               "AAPL xfinancearrowdownx",
+		"Oil ↓ today":                "Oil xfinancearrowdownx today",
+		"Gold down $4.50":            "Gold xfinancemoneydownx",
+		"Gold +$1,250.75":            "Gold xfinancemoneyupx",
+		"Yields +25bp":               "Yields xfinancebpupx",
+		"Spreads -10 basis points":   "Spreads xfinancebpdownx",
+		"Revenue +2.5% year on year": "Revenue xpositivepercentx year on year",
+		"Up 3 floors":                "Up 3 floors",
+		"Tickets cost $4.50":         "Tickets cost $4.50",
+	}
+
+	for text, expected := range tests {
+		if replaced := strings.Join(strings.Fields(recognizers.Replace(text)), " "); replaced != expected {
+			t.Errorf("unexpected replacement of %q: %q, expected %q", text, replaced, expected)
+		}
+	}
+}
+
+func TestNumberFormat(t *testing.T) {
+	point := FinanceRecognizers(PointDecimalFormat)
+	comma := FinanceRecognizers(CommaDecimalFormat)
+
+	tests := []struct {
+		recognizers Recognizers
+		text        string
+		expected    string
+	}{
+		{point, "DAX +1,5%", "DAX +1,5%"},
+		{comma, "DAX +1,5%", "DAX xpositivepercentx"},
+		{comma, "DAX fell 1.234,5 points", "DAX xfinancepointsdownx"},
+		{comma, "DAX fell 1 234,5 points", "DAX xfinancepointsdownx"},
+		{point, "DAX fell 1,234.5 points", "DAX xfinancepointsdownx"},
+		{FinanceRecognizers(NumberFormat{}), "DAX +1,5% +1.5%", "DAX xpositivepercentx xpositivepercentx"},
+		// numbers of other format are not matched partially
+		{comma, "Gold down $4.50", "Gold down $4.50"},
+		{comma, "Gold up $1,000,000", "Gold up $1,000,000"},
+		{point, "AAPL ▲1,2", "AAPL ▲1,2"},
+		{comma, "Gold down $4,50", "Gold xfinancemoneydownx"},
+		{comma, "Gold up $1.000.000, then down $3", "Gold xfinancemoneyupx , then xfinancemoneydownx"},
+		{point, "Gold down $4.50.", "Gold xfinancemoneydownx ."},
+		{point, "AAPL ▲1.2 and ▼0,5", "AAPL xfinancearrowupx and ▼0,5"},
+	}
+
+	for _, test := range tests {
+		if replaced := strings.Join(strings.Fields(test.recognizers.Replace(test.text)), " "); replaced != test.expected {
+			t.Errorf("unexpected replacement of %q: %q, expected %q", test.text, replaced, test.expected)
+		}
+	}
+}
+
+func TestWithRecognizers(t *testing.T) {
+	plain, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recognizers := FinanceRecognizers(PointDecimalFormat)
+	if !recognizers.SetValence(FinancePointsUp, 1.5) || recognizers.SetValence("xunknownx", 1) {
+		t.Error("unexpected result of SetValence")
+	}
+	sia, err := plain.With(WithRecognizers(recognizers))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plain.PolarityScores("Dow up 3 points").Compound != 0 {
+		t.Error("points recognized without recognizer")
+	}
+
+	score := func(text string) float64 {
+		return sia.PolarityScores(text).Compound
+	}
+	if score("Dow up 3 points") <= 0 || score("Gold down $4.50") >= 0 || score("AAPL ▲1.2") <= 0 || score("Yields -25bp") >= 0 {
+		t.Error("unexpected polarity of finance changes")
+	}
+	if score("Stock +2%") != plain.PolarityScores("Stock +2%").Compound {
+		t.Error("percentages scored differently by finance preset")
+	}
+
+	// analyzer is not changed through recognizers given to WithRecognizers
+	before := sia.PolarityScores("Dow up 3 points")
+	recognizers.SetValence(FinancePointsUp, -3)
+	recognizers[0].(*PatternRecognizer).Placeholder = "xchangedx"
+	if sia.PolarityScores("Dow up 3 points") != before || score("AAPL ▲1.2") <= 0 {
+		t.Error("analyzer changed through recognizers")
+	}
+
+	if entry, ok := sia.LookupLexicon(FinancePointsUp); !ok || entry.Mean != 1.5 {
+		t.Errorf("unexpected placeholder entry: %+v", entry)
+	}
+	if layer, _ := sia.LexiconSource(FinanceArrowDown); layer != "recognizers" {
+		t.Errorf("unexpected placeholder layer: %q", layer)
+	}
+	if layer, _ := sia.LexiconSource("xpositivepercentx"); layer != "vader_lexicon.txt" {
+		t.Errorf("unchanged placeholder moved to layer %q", layer)
+	}
+	if len(sia.LexiconConflicts()) != 0 {
+		t.Errorf("unexpected conflicts: %+v", sia.LexiconConflicts())
+	}
+
+	withoutPercentages, err := New(WithRecognizers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withoutPercentages.PolarityScores("Stock +2%").Compound != 0 {
+		t.Error("percentages recognized without recognizers")
+	}
+
+	ratings, err := sia.With(WithRatings(NewRatingRecognizer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ratings.PolarityScores("Dow up 3 points, 9/10").Compound <= score("Dow up 3 points") {
+		t.Error("rating recognizer not added to recognizers")
+	}
+
+	for _, recognizer := range []Recognizer{nil, &PatternRecognizer{Placeholder: "xemptyx"}} {
+		if _, err := New(WithRecognizers(recognizer)); err == nil {
+			t.Errorf("expected error for recognizer %+v", recognizer)
+		}
+	}
+}
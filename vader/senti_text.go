@@ -11,7 +11,11 @@ type SentiText struct {
 }
 
 func NewSentiText(text string) *SentiText {
-	wordsAndEmoticons := CleanWordsAndEmoticons(text)
+	return NewSentiTextFromTokens(CleanWordsAndEmoticons(text))
+}
+
+// NewSentiTextFromTokens creates SentiText from tokens of a Tokenizer
+func NewSentiTextFromTokens(wordsAndEmoticons []string) *SentiText {
 	isCapDiff := IsAllCapDiff(wordsAndEmoticons)
 
 	wordsAndEmoticonsLower := make([]string, 0, len(wordsAndEmoticons))
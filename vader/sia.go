@@ -1,78 +1,227 @@
 package vader
 
 import (
-	"io/ioutil"
 	"math"
 	"strings"
 
 	"github.com/gonum/floats"
 )
 
-//Give a sentiment intensity score to sentences.
-type SentimentIntensityAnalyzer struct {
-	LexiconMap        map[string]float64
-	EmojiLexiconMap   map[string]string
-	SpecialCaseIdioms map[string]float64
+// Analyzer gives a sentiment intensity score to sentences.
+//
+// Analyzer is built by New and cannot be changed afterwards, so it is safe for
+// concurrent use by multiple goroutines. Changes are made by deriving a new
+// analyzer with With. Zero value of Analyzer is not usable.
+type Analyzer struct {
+	lexicon      map[string]LexiconEntry
+	emojiLexicon map[string]string
+	emojiAliases map[string]string
+	options      Options
+	skippedLines []*ParseError
+	language     *LanguagePack
+	tokenizer    Tokenizer
+	// emoji are scored by valences of lexicon instead of descriptions
+	emojiValences bool
+	// applied to text before tokenization
+	recognizers Recognizers
+
+	// names of lexicon layers starting with base lexicon,
+	// layers which supplied tokens not from base lexicon and redefinitions
+	lexiconLayers    []string
+	lexiconOrigins   map[string]string
+	lexiconConflicts []LexiconConflict
+
+	// analyzer's own copies of negation, booster and idiom tables
+	negations            map[string]bool
+	boosters             map[string]float64
+	specialCaseIdioms    map[string]float64
+	sentimentLadenIdioms map[string]float64
+	// sentiment laden idioms split to words, longer first, sorted when analyzer is built
+	sortedLadenIdioms []ladenIdiom
 }
 
-// Initialize sentiment analyzer with lexicons
-// if no filepaths passed to init, using default lexicon files
-func (sia *SentimentIntensityAnalyzer) Init(filenames ...string) error {
-	var lexiconFilename string
-	var emojiLexiconFilename string
+// Sentiment scores of a text.
+// Neg, Neu and Pos are proportions of text that fall in each category,
+// Compound is normalized, weighted composite score between -1 and 1.
+type SentimentScores struct {
+	Neg      float64 `json:"neg"`
+	Neu      float64 `json:"neu"`
+	Pos      float64 `json:"pos"`
+	Compound float64 `json:"compound"`
+}
+
+// Map returns scores as map with "neg", "neu", "pos" and "compound" keys
+func (s SentimentScores) Map() map[string]float64 {
+	return map[string]float64{
+		"neg":      s.Neg,
+		"neu":      s.Neu,
+		"pos":      s.Pos,
+		"compound": s.Compound,
+	}
+}
+
+// New builds sentiment analyzer configured by options,
+// if no lexicon option is given, default lexicons built into the package are used
+func New(opts ...Option) (*Analyzer, error) {
+	return build(nil, opts)
+}
+
+// With derives a new analyzer from sia with options applied on top of its configuration,
+// sia itself stays unchanged
+func (sia *Analyzer) With(opts ...Option) (*Analyzer, error) {
+	return build(sia, opts)
+}
+
+// Options returns heuristic constants of the analyzer
+func (sia *Analyzer) Options() Options {
+	return sia.options
+}
+
+// SkippedLines lists malformed lexicon lines skipped in Lenient parse mode
+func (sia *Analyzer) SkippedLines() []*ParseError {
+	return append([]*ParseError(nil), sia.skippedLines...)
+}
+
+func build(parent *Analyzer, opts []Option) (*Analyzer, error) {
+	b := &builder{}
+	for _, opt := range opts {
+		if err := opt(b); err != nil {
+			return nil, err
+		}
+	}
+
+	if b.options != nil {
+		if err := b.options.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var sia *Analyzer
+	if parent == nil {
+		sia = &Analyzer{options: DefaultOptions()}
+		if b.options != nil {
+			sia.options = *b.options
+		}
+		sia.language = english
+		sia.recognizers = defaultRecognizers
+		if b.language != nil {
+			sia.language = b.language
+		}
+		sia.initTables()
 
-	if len(filenames) == 2 {
-		lexiconFilename = filenames[0]
-		emojiLexiconFilename = filenames[1]
+		if b.lexicons == nil && b.state == nil {
+			b.lexicons = sia.language.source()
+		}
 	} else {
-		lexiconFilename = "../data/vader_lexicon.txt"
-		emojiLexiconFilename = "../data/emoji_utf8_lexicon.txt"
+		sia = parent.clone()
+		if b.options != nil {
+			sia.options = *b.options
+			sia.rescaleBoosters(parent.options)
+		}
+		if b.language != nil {
+			sia.language = b.language
+			sia.initTables()
+			if b.lexicons == nil && b.state == nil {
+				b.lexicons = sia.language.source()
+			}
+		}
+	}
+
+	if b.lexicons != nil {
+		if err := sia.load(b.lexicons, b.parseMode); err != nil {
+			return nil, err
+		}
+	}
+	if b.state != nil {
+		sia.loadState(b.state)
+	}
+
+	for _, edit := range b.edits {
+		edit(sia)
 	}
+	sia.sortLadenIdioms()
 
-	// load lexicon file
-	lexicon, err := ioutil.ReadFile(lexiconFilename)
+	return sia, nil
+}
+
+// copy of analyzer with own tables, immutable lexicons are shared
+func (sia *Analyzer) clone() *Analyzer {
+	clone := *sia
+	clone.negations = make(map[string]bool, len(sia.negations))
+	for negation := range sia.negations {
+		clone.negations[negation] = true
+	}
+	clone.boosters = copyTable(sia.boosters)
+	clone.specialCaseIdioms = copyTable(sia.specialCaseIdioms)
+	clone.sentimentLadenIdioms = copyTable(sia.sentimentLadenIdioms)
+
+	return &clone
+}
+
+// parse lexicons according to parse mode
+func (sia *Analyzer) load(source *lexiconSource, mode ParseMode) error {
+	lexicon, skipped, err := MakeLexiconEntries(source.lexiconName, source.lexicon, mode)
 	if err != nil {
 		return err
 	}
-	sia.LexiconMap = MakeLexiconMap(string(lexicon))
 
-	// load emoji lexicon file
-	emojiLexicon, err := ioutil.ReadFile(emojiLexiconFilename)
+	emojiLexiconMap, skippedEmoji, err := MakeEmojiLexiconMap(source.emojiLexiconName, source.emojiLexicon, mode)
 	if err != nil {
 		return err
 	}
-	sia.EmojiLexiconMap = MakeEmojiLexiconMap(string(emojiLexicon))
 
-	//set special case idioms for analyzer
-	sia.SpecialCaseIdioms = SpecialCaseIdioms
+	sia.lexicon = lexicon
+	sia.emojiLexicon = emojiLexiconMap
+	sia.emojiAliases = emojiAliases(emojiLexiconMap)
+	sia.skippedLines = append(skipped, skippedEmoji...)
+
+	sia.lexiconLayers = []string{source.lexiconName}
+	sia.lexiconOrigins = nil
+	sia.lexiconConflicts = nil
 
 	return nil
 }
 
 // Return a float for sentiment strength based on the input text.
 // Positive values are positive valence, negative value are negative valence.
-func (sia *SentimentIntensityAnalyzer) PolarityScores(text string) map[string]float64 {
-	if strings.Contains(text, "%") {
-		text = ReplacePercentages(text)
+func (sia *Analyzer) PolarityScores(text string) SentimentScores {
+	sentiments, text := sia.tokenSentiments(text, nil, nil)
+
+	return sia.scoreValence(sentiments, text)
+}
+
+// Compute valence for each token of the text, if tracer is not nil
+// rules applied to each token are recorded to it. Valences, if not nil,
+// override mean valences of lexicon tokens.
+// Returns valences and text after preprocessing.
+func (sia *Analyzer) tokenSentiments(text string, tr *tracer, valences map[string]float64) ([]float64, string) {
+	if sia.lexicon == nil {
+		panic("vader: Analyzer must be created with New")
 	}
 
-	// create list of tokens from text
+	text = sia.recognizers.Replace(text)
+
+	// create list of tokens from text, emoji are split from other text
 	var textTokensList []string
 	for _, token := range strings.Fields(text) {
-		if EmojisRegexp.Match([]byte(token)) {
-			emoticons := strings.Split(token, "")
-			for _, emoticon := range emoticons {
-				textTokensList = append(textTokensList, emoticon)
-			}
+		if EmojisRegexp.MatchString(token) {
+			textTokensList = append(textTokensList, SplitEmoji(token)...)
 		} else {
 			textTokensList = append(textTokensList, token)
 		}
 	}
 
-	// replace all emojis with its description
+	// replace all emojis with its description, unless they have own valence
 	textNoEmojiList := make([]string, 0, len(textTokensList))
 	for _, token := range textTokensList {
-		if description, ok := sia.EmojiLexiconMap[token]; ok {
+		if sia.emojiValences {
+			if emoji, ok := sia.emojiToken(token); ok {
+				textNoEmojiList = append(textNoEmojiList, emoji)
+				continue
+			}
+		}
+
+		if description, ok := sia.emojiDescription(token); ok {
 			textNoEmojiList = append(textNoEmojiList, description)
 		} else {
 			textNoEmojiList = append(textNoEmojiList, token)
@@ -81,105 +230,122 @@ func (sia *SentimentIntensityAnalyzer) PolarityScores(text string) map[string]fl
 
 	// prepare sentiText for further processing
 	text = strings.TrimSpace(strings.Join(textNoEmojiList, " "))
-	sentiText := NewSentiText(text)
+	sentiText := sia.sentiText(text)
+	tr.init(sentiText.WordsAndEmoticons)
 
 	sentiments := make([]float64, 0, len(sentiText.WordsAndEmoticonsLower))
 	for wordIndex, word := range sentiText.WordsAndEmoticonsLower {
 		valence := 0.0
 
 		// check for vader_lexicon words that may be used as modifiers or negations
-		if _, ok := BoosterMap[word]; ok {
+		if _, ok := sia.boosters[word]; ok {
 			sentiments = append(sentiments, valence)
-		} else if wordIndex < len(sentiText.WordsAndEmoticonsLower)-1 && word == "kind" && sentiText.WordsAndEmoticonsLower[wordIndex+1] == "of" {
+		} else if wordIndex < len(sentiText.WordsAndEmoticonsLower)-1 && sia.language.startsNeutralPhrase(sentiText.WordsAndEmoticonsLower, wordIndex) {
 			sentiments = append(sentiments, valence)
 		} else {
-			sentiments = sia.sentimentValence(valence, sentiText, word, wordIndex, sentiments)
+			sentiments = sia.sentimentValence(valence, sentiText, word, wordIndex, sentiments, tr, valences)
 		}
 	}
 
-	sentiments = butCheck(sentiText.WordsAndEmoticonsLower, sentiments)
-	valenceDict := sia.scoreValence(sentiments, text)
+	sentiments = sia.sentimentLadenIdiomsCheck(sentiText, sentiments, tr)
+	sentiments = sia.butCheck(sentiText.WordsAndEmoticonsLower, sentiments, tr)
+	tr.finish(sentiments)
 
-	return valenceDict
+	return sentiments, text
 }
 
-func (sia *SentimentIntensityAnalyzer) sentimentValence(valence float64, sentiText *SentiText, token string, tokenIndex int, sentiments []float64) []float64 {
+func (sia *Analyzer) sentimentValence(valence float64, sentiText *SentiText, token string, tokenIndex int, sentiments []float64, tr *tracer, valences map[string]float64) []float64 {
 	//get the sentiment valence
-	if value, ok := sia.LexiconMap[token]; ok {
+	if entry, ok := sia.lexicon[token]; ok {
+		value := entry.Mean
+		if override, ok := valences[token]; ok {
+			value = override
+		}
 		valence = value
+		tr.lexicon(tokenIndex, value)
 
 		//check for "no" as negation for an adjacent lexicon item vs "no" as its own stand-alone lexicon item
-		if token == "no" && tokenIndex != len(sentiText.WordsAndEmoticons)-1 {
-			if _, found := sia.LexiconMap[sentiText.WordsAndEmoticonsLower[tokenIndex+1]]; found {
+		determiner := sia.language.NegativeDeterminer
+		if determiner != "" && token == determiner && tokenIndex != len(sentiText.WordsAndEmoticons)-1 {
+			if _, found := sia.lexicon[sentiText.WordsAndEmoticonsLower[tokenIndex+1]]; found {
 				// don't use valence of "no" as a lexicon item. Instead set it's valence to 0.0 and negate the next item
+				tr.record(tokenIndex, RuleNo, valence, 0.0)
 				valence = 0.0
 			}
 
-			if (tokenIndex > 0 && sentiText.WordsAndEmoticonsLower[tokenIndex-1] == "no") ||
-				(tokenIndex > 1 && sentiText.WordsAndEmoticonsLower[tokenIndex-2] == "no") ||
-				(tokenIndex > 2 && sentiText.WordsAndEmoticonsLower[tokenIndex-3] == "no" &&
-					(sentiText.WordsAndEmoticonsLower[tokenIndex-1] == "or" || sentiText.WordsAndEmoticonsLower[tokenIndex-1] == "nor")) {
-				valence = value * N_SCALAR
+			if (tokenIndex > 0 && sentiText.WordsAndEmoticonsLower[tokenIndex-1] == determiner) ||
+				(tokenIndex > 1 && sentiText.WordsAndEmoticonsLower[tokenIndex-2] == determiner) ||
+				(tokenIndex > 2 && sentiText.WordsAndEmoticonsLower[tokenIndex-3] == determiner &&
+					containsWord(sia.language.Disjunctions, sentiText.WordsAndEmoticonsLower[tokenIndex-1])) {
+				tr.record(tokenIndex, RuleNo, valence, value*sia.options.NScalar)
+				valence = value * sia.options.NScalar
 			}
 		}
 
 		//check if sentiment laden word is in ALL CAPS (while others aren't)
-		if token == strings.ToUpper(token) && sentiText.IsCapDiff {
+		if isAllCaps(sentiText.WordsAndEmoticons[tokenIndex]) && sentiText.IsCapDiff {
+			before := valence
 			if valence > 0 {
-				valence += C_INCR
+				valence += sia.options.CIncr
 			} else {
-				valence -= C_INCR
+				valence -= sia.options.CIncr
 			}
+			tr.record(tokenIndex, RuleAllCaps, before, valence)
 		}
 
 		//check preceding words modifiers
 		for startIndex := 0; startIndex < 3; startIndex++ {
 			if tokenIndex > startIndex {
-				if _, ok := sia.LexiconMap[sentiText.WordsAndEmoticonsLower[tokenIndex-(startIndex+1)]]; !ok {
+				if _, ok := sia.lexicon[sentiText.WordsAndEmoticonsLower[tokenIndex-(startIndex+1)]]; !ok {
 					// add boost value to actual valence
-					valence += getBoostValue(sentiText.WordsAndEmoticonsLower[tokenIndex-(startIndex+1)], startIndex, valence, sentiText.IsCapDiff)
+					before := valence
+					valence += sia.getBoostValue(sentiText, tokenIndex-(startIndex+1), startIndex, valence)
+					tr.record(tokenIndex, RuleBooster, before, valence)
 
 					// check negation
-					valence = sia.negationCheck(valence, sentiText.WordsAndEmoticonsLower, tokenIndex)
+					before = valence
+					valence = sia.negationCheckAt(valence, sentiText.WordsAndEmoticonsLower, startIndex, tokenIndex)
+					tr.record(tokenIndex, RuleNegation, before, valence)
 				}
 			}
 		}
 	}
 
 	//check special case idioms
-	valence = sia.specialIdiomsCheck(valence, sentiText.WordsAndEmoticonsLower, tokenIndex)
+	valence = sia.specialIdiomsCheck(valence, sentiText.WordsAndEmoticonsLower, tokenIndex, tr)
 
 	sentiments = append(sentiments, valence)
 	return sentiments
 }
 
-// check boost of previous words
-func getBoostValue(token string, startIndex int, valence float64, isCapDiff bool) float64 {
-	boost := scalarIncDec(token, valence, isCapDiff)
+// check boost of previous word at index
+func (sia *Analyzer) getBoostValue(sentiText *SentiText, index int, startIndex int, valence float64) float64 {
+	boost := sia.scalarIncDec(sentiText.WordsAndEmoticonsLower[index], sentiText.WordsAndEmoticons[index], valence, sentiText.IsCapDiff)
 	if boost != 0 {
 		switch startIndex {
 		case 0:
 			boost *= 1
 		case 1:
-			boost *= 0.95
+			boost *= sia.options.BoosterDecay[0]
 		case 2:
-			boost *= 0.9
+			boost *= sia.options.BoosterDecay[1]
 		}
 	}
 
 	return boost
 }
 
-func butCheck(wordsAndEmoticons []string, sentiments []float64) []float64 {
-	// check for modification in sentiment due to contrastive conjunction 'but'
+func (sia *Analyzer) butCheck(wordsAndEmoticons []string, sentiments []float64, tr *tracer) []float64 {
+	// check for modification in sentiment due to contrastive conjunction, e.g. 'but'
 	for wi, word := range wordsAndEmoticons {
-		if word == "but" {
+		if containsWord(sia.language.ContrastiveConjunctions, word) {
 			for si, sentiment := range sentiments {
 				if si < wi {
-					sentiments[si] = sentiment * 0.5
+					sentiments[si] = sentiment * sia.options.ButBefore
 				} else if si > wi {
-					sentiments[si] = sentiment * 1.5
+					sentiments[si] = sentiment * sia.options.ButAfter
 				}
+				tr.record(si, RuleBut, sentiment, sentiments[si])
 			}
 		}
 	}
@@ -187,7 +353,7 @@ func butCheck(wordsAndEmoticons []string, sentiments []float64) []float64 {
 	return sentiments
 }
 
-func (sia *SentimentIntensityAnalyzer) specialIdiomsCheck(valence float64, wordsAndEmoticons []string, tokenIndex int) float64 {
+func (sia *Analyzer) specialIdiomsCheck(valence float64, wordsAndEmoticons []string, tokenIndex int, tr *tracer) float64 {
 	if len(wordsAndEmoticons) == 0 {
 		return valence
 	}
@@ -218,7 +384,8 @@ func (sia *SentimentIntensityAnalyzer) specialIdiomsCheck(valence float64, words
 	}
 
 	for key, ngram := range ngrams {
-		if value, ok := sia.SpecialCaseIdioms[ngram]; ok {
+		if value, ok := sia.specialCaseIdioms[ngram]; ok {
+			tr.record(tokenIndex, RuleSpecialIdiom, valence, value)
 			valence = value
 
 			if key != "zeroOne" && key != "zeroOneTwo" {
@@ -233,27 +400,76 @@ func (sia *SentimentIntensityAnalyzer) specialIdiomsCheck(valence float64, words
 	possibleBoosters := []string{ngrams["threeTwoOne"], ngrams["threeTwo"], ngrams["twoOne"]}
 	// check for booster/dampener bi-grams such as 'sort of' or 'kind of'
 	for _, ngram := range possibleBoosters {
-		if value, ok := BoosterMap[ngram]; ok {
+		if value, ok := sia.boosters[ngram]; ok {
+			tr.record(tokenIndex, RuleBooster, valence, valence+value)
 			valence = valence + value
 		}
 	}
 
 	if valence != 0 {
+		before := valence
 		valence = sia.negationCheck(valence, wordsAndEmoticons, specialCaseIdiomStartIndex)
+		tr.record(tokenIndex, RuleNegation, before, valence)
 	}
 
 	return valence
 }
 
-// Future Work
-// check for sentiment laden idioms that don't contain a lexicon word
-func (sia *SentimentIntensityAnalyzer) sentimentLadenIdiomsCheck(valence float64, text string) float64 {
-	// TODO in future
-	return 0.0
+// check for sentiment laden idioms that don't contain a lexicon word,
+// idiom is matched on whole tokens and its valence, affected by preceding boosters and negations,
+// is assigned to the first token of idiom, while other tokens of idiom become neutral
+func (sia *Analyzer) sentimentLadenIdiomsCheck(sentiText *SentiText, sentiments []float64, tr *tracer) []float64 {
+	words := sentiText.WordsAndEmoticonsLower
+
+	matched := make([]bool, len(words))
+	for _, idiom := range sia.sortedLadenIdioms {
+		idiomWords := idiom.words
+		for i := 0; i+len(idiomWords) <= len(words); i++ {
+			if !matchIdiom(words[i:i+len(idiomWords)], matched[i:i+len(idiomWords)], idiomWords) {
+				continue
+			}
+
+			valence := idiom.valence
+
+			//check preceding words modifiers
+			for startIndex := 0; startIndex < 3; startIndex++ {
+				if i > startIndex {
+					if _, ok := sia.lexicon[words[i-(startIndex+1)]]; !ok {
+						valence += sia.getBoostValue(sentiText, i-(startIndex+1), startIndex, valence)
+					}
+				}
+			}
+			valence = sia.negationCheck(valence, words, i)
+
+			tr.record(i, RuleSentimentLadenIdiom, sentiments[i], valence)
+			sentiments[i] = valence
+			matched[i] = true
+			for j := i + 1; j < i+len(idiomWords); j++ {
+				tr.record(j, RuleSentimentLadenIdiom, sentiments[j], 0)
+				sentiments[j] = 0
+				matched[j] = true
+			}
+
+			i += len(idiomWords) - 1
+		}
+	}
+
+	return sentiments
+}
+
+// check whether words are equal to idiom words and not matched by another idiom yet
+func matchIdiom(words []string, matched []bool, idiomWords []string) bool {
+	for i, word := range idiomWords {
+		if matched[i] || words[i] != word {
+			return false
+		}
+	}
+
+	return true
 }
 
 //check for negations
-func (sia *SentimentIntensityAnalyzer) negationCheck(valence float64, wordsAndEmoticons []string, tokenIndex int) float64 {
+func (sia *Analyzer) negationCheck(valence float64, wordsAndEmoticons []string, tokenIndex int) float64 {
 	if len(wordsAndEmoticons) == 0 {
 		return valence
 	}
@@ -261,34 +477,71 @@ func (sia *SentimentIntensityAnalyzer) negationCheck(valence float64, wordsAndEm
 	//check previous words for negations
 	switch i := tokenIndex; {
 	case i > 2:
-		if wordsAndEmoticons[tokenIndex-3] == "never" &&
-			((wordsAndEmoticons[tokenIndex-2] == "so" || wordsAndEmoticons[tokenIndex-2] == "this") ||
-				(wordsAndEmoticons[tokenIndex-1] == "so" || wordsAndEmoticons[tokenIndex-1] == "this")) {
+		if sia.language.isEmphaticNegation(wordsAndEmoticons[tokenIndex-3], wordsAndEmoticons[tokenIndex-2]) ||
+			sia.language.isEmphaticNegation(wordsAndEmoticons[tokenIndex-3], wordsAndEmoticons[tokenIndex-1]) {
 			return valence * 1.25
-		} else if wordsAndEmoticons[tokenIndex-3] == "without" && (wordsAndEmoticons[tokenIndex-2] == "doubt" || wordsAndEmoticons[tokenIndex-1] == "doubt") {
+		} else if sia.language.isNegationCanceller(wordsAndEmoticons[tokenIndex-3], wordsAndEmoticons[tokenIndex-2]) ||
+			sia.language.isNegationCanceller(wordsAndEmoticons[tokenIndex-3], wordsAndEmoticons[tokenIndex-1]) {
 			return valence
-		} else if ContainsNegation(wordsAndEmoticons[tokenIndex-3 : tokenIndex]) { //3 words preceding the lexicon word position
-			return valence * N_SCALAR
+		} else if containsNegation(sia.isNegation, sia.language, sia.options.IncludeNt, wordsAndEmoticons[tokenIndex-3 : tokenIndex]) { //3 words preceding the lexicon word position
+			return valence * sia.options.NScalar
 		}
 	case i > 1:
-		if wordsAndEmoticons[tokenIndex-2] == "never" && (wordsAndEmoticons[tokenIndex-1] == "so" || wordsAndEmoticons[tokenIndex-1] == "this") {
+		if sia.language.isEmphaticNegation(wordsAndEmoticons[tokenIndex-2], wordsAndEmoticons[tokenIndex-1]) {
 			return valence * 1.25
-		} else if wordsAndEmoticons[tokenIndex-2] == "without" && wordsAndEmoticons[tokenIndex-1] == "doubt" {
+		} else if sia.language.isNegationCanceller(wordsAndEmoticons[tokenIndex-2], wordsAndEmoticons[tokenIndex-1]) {
 			return valence
-		} else if ContainsNegation(wordsAndEmoticons[tokenIndex-2 : tokenIndex]) { // 2 words preceding the lexicon word position
-			return valence * N_SCALAR
+		} else if containsNegation(sia.isNegation, sia.language, sia.options.IncludeNt, wordsAndEmoticons[tokenIndex-2 : tokenIndex]) { // 2 words preceding the lexicon word position
+			return valence * sia.options.NScalar
 		}
 	case i > 0:
-		if ContainsNegation(wordsAndEmoticons[tokenIndex-1 : tokenIndex]) { // 1 word preceding lexicon word (w/o stopwords)
-			return valence * N_SCALAR
+		if containsNegation(sia.isNegation, sia.language, sia.options.IncludeNt, wordsAndEmoticons[tokenIndex-1 : tokenIndex]) { // 1 word preceding lexicon word (w/o stopwords)
+			return valence * sia.options.NScalar
+		}
+	}
+
+	return valence
+}
+
+// check negation by the word startIndex+1 positions before token, preceding words are
+// checked one by one as their modifiers are, so that each negation is applied once
+func (sia *Analyzer) negationCheckAt(valence float64, wordsAndEmoticons []string, startIndex int, tokenIndex int) float64 {
+	switch startIndex {
+	case 1:
+		if sia.language.isEmphaticNegation(wordsAndEmoticons[tokenIndex-2], wordsAndEmoticons[tokenIndex-1]) {
+			return valence * 1.25
+		} else if sia.language.isNegationCanceller(wordsAndEmoticons[tokenIndex-2], wordsAndEmoticons[tokenIndex-1]) {
+			return valence
 		}
+	case 2:
+		if sia.language.isEmphaticNegation(wordsAndEmoticons[tokenIndex-3], wordsAndEmoticons[tokenIndex-2]) ||
+			sia.language.isEmphaticNegation(wordsAndEmoticons[tokenIndex-3], wordsAndEmoticons[tokenIndex-1]) {
+			return valence * 1.25
+		} else if sia.language.isNegationCanceller(wordsAndEmoticons[tokenIndex-3], wordsAndEmoticons[tokenIndex-2]) ||
+			sia.language.isNegationCanceller(wordsAndEmoticons[tokenIndex-3], wordsAndEmoticons[tokenIndex-1]) {
+			return valence
+		}
+	}
+
+	if sia.negatesAt(wordsAndEmoticons, tokenIndex-(startIndex+1)) {
+		return valence * sia.options.NScalar
 	}
 
 	return valence
 }
 
+// check whether word at index is a negation, conditional negation is checked against preceding word
+func (sia *Analyzer) negatesAt(wordsAndEmoticons []string, index int) bool {
+	word := wordsAndEmoticons[index]
+	if exceptions, ok := sia.language.ConditionalNegations[word]; ok {
+		return index > 0 && !containsWord(exceptions, wordsAndEmoticons[index-1])
+	}
+
+	return containsNegation(sia.isNegation, sia.language, sia.options.IncludeNt, wordsAndEmoticons[index:index+1])
+}
+
 // add emphasis from exclamation points and question marks
-func (sia *SentimentIntensityAnalyzer) punctuationEmphasis(text string) float64 {
+func (sia *Analyzer) punctuationEmphasis(text string) float64 {
 	epAmplifier := sia.amplifyEP(text)
 	qmAmplifier := sia.amplifyQM(text)
 
@@ -296,24 +549,24 @@ func (sia *SentimentIntensityAnalyzer) punctuationEmphasis(text string) float64
 }
 
 // check for added emphasis resulting from exclamation points (up to 4 of them)
-func (sia *SentimentIntensityAnalyzer) amplifyEP(text string) float64 {
+func (sia *Analyzer) amplifyEP(text string) float64 {
 	epCount := strings.Count(text, "!")
-	if epCount > MaxEM {
-		epCount = MaxEM
+	if epCount > sia.options.MaxEM {
+		epCount = sia.options.MaxEM
 	}
 
 	// (empirically derived mean sentiment intensity rating increase for exclamation points)
-	return float64(epCount) * 0.292
+	return float64(epCount) * sia.options.EPIncr
 }
 
 // check for added emphasis resulting from question marks (2 or 3+)
-func (sia *SentimentIntensityAnalyzer) amplifyQM(text string) float64 {
+func (sia *Analyzer) amplifyQM(text string) float64 {
 	qmCount := strings.Count(text, "?")
 	if qmCount > 1 {
-		if qmCount <= MaxQM {
-			return float64(qmCount) * 0.18
+		if qmCount <= sia.options.MaxQM {
+			return float64(qmCount) * sia.options.QMIncr
 		} else {
-			return 0.96
+			return sia.options.QMMax
 		}
 	}
 
@@ -321,7 +574,7 @@ func (sia *SentimentIntensityAnalyzer) amplifyQM(text string) float64 {
 }
 
 // want separate positive versus negative sentiment scores
-func (sia *SentimentIntensityAnalyzer) siftSentimentScores(sentiments []float64) (float64, float64, float64) {
+func (sia *Analyzer) siftSentimentScores(sentiments []float64) (float64, float64, float64) {
 	posSum := 0.0
 	negSum := 0.0
 	neuCount := 0.0
@@ -339,7 +592,7 @@ func (sia *SentimentIntensityAnalyzer) siftSentimentScores(sentiments []float64)
 	return posSum, negSum, neuCount
 }
 
-func (sia *SentimentIntensityAnalyzer) scoreValence(sentiments []float64, text string) map[string]float64 {
+func (sia *Analyzer) scoreValence(sentiments []float64, text string) SentimentScores {
 	var compound float64
 	var pos float64
 	var neg float64
@@ -355,7 +608,7 @@ func (sia *SentimentIntensityAnalyzer) scoreValence(sentiments []float64, text s
 		} else if sumS < 0 {
 			sumS -= punctEmphAmplifier
 		}
-		compound = Normalize(sumS)
+		compound = normalize(sumS, sia.options.Alpha)
 
 		// discriminate between positive, negative and neutral sentiment scores
 		posSum, negSum, neuCount := sia.siftSentimentScores(sentiments)
@@ -372,32 +625,30 @@ func (sia *SentimentIntensityAnalyzer) scoreValence(sentiments []float64, text s
 		neu = math.Abs(neuCount / total)
 	}
 
-	sentimentMap := map[string]float64{
-		"pos":      floats.Round(pos, 3),
-		"neg":      floats.Round(neg, 3),
-		"neu":      floats.Round(neu, 3),
-		"compound": floats.Round(compound, 4),
+	return SentimentScores{
+		Neg:      floats.Round(neg, 3),
+		Neu:      floats.Round(neu, 3),
+		Pos:      floats.Round(pos, 3),
+		Compound: floats.Round(compound, 4),
 	}
-
-	return sentimentMap
 }
 
 // Check if the preceding words increase, decrease, or negate/nullify the
 // valence
-func scalarIncDec(word string, valence float64, isCapDiff bool) float64 {
+func (sia *Analyzer) scalarIncDec(word, original string, valence float64, isCapDiff bool) float64 {
 	var scalar float64
 
-	if value, ok := BoosterMap[word]; ok {
+	if value, ok := sia.boosters[word]; ok {
 		scalar = value
 		if valence < 0 {
 			scalar *= -1
 		}
 		//check if booster/dampener word is in ALLCAPS (while others aren't)
-		if word == strings.ToUpper(word) && isCapDiff {
+		if isAllCaps(original) && isCapDiff {
 			if valence > 0 {
-				scalar += C_INCR
+				scalar += sia.options.CIncr
 			} else {
-				scalar -= C_INCR
+				scalar -= sia.options.CIncr
 			}
 		}
 	}
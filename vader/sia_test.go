@@ -1,21 +1,146 @@
 package vader
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
-func TestSentimentIntensityAnalyzer_Init(t *testing.T) {
-	sia := SentimentIntensityAnalyzer{}
-	err := sia.Init()
+func TestNew(t *testing.T) {
+	_, err := New()
 	if err != nil {
 		t.Fatal(err)
 	}
 }
 
-func TestSentimentIntensityAnalyzer_PolarityScores_Emoticons(t *testing.T) {
-	sia := SentimentIntensityAnalyzer{}
-	err := sia.Init()
+func TestNew_LexiconFiles(t *testing.T) {
+	_, err := New(WithLexiconFiles("../data/vader_lexicon.txt", "../data/emoji_utf8_lexicon.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = New(WithLexiconFiles("missing_lexicon.txt", "missing_emoji_lexicon.txt"))
+	if err == nil {
+		t.Error("expected error for missing lexicon files")
+	}
+}
+
+func TestNew_LexiconReaders(t *testing.T) {
+	sia, err := New(WithLexiconReaders(strings.NewReader("good\t1.9\t0.9\t[2, 2, 2, 2, 2, 2, 2, 2, 2, 1]\n"), strings.NewReader("😁\tbeaming face")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sia.lexicon) != 1 || sia.lexicon["good"].Mean != 1.9 || sia.emojiLexicon["😁"] != "beaming face" {
+		t.Errorf("unexpected lexicons: %v %v", sia.lexicon, sia.emojiLexicon)
+	}
+
+	if _, err := New(WithLexiconReaders(nil, nil)); err == nil {
+		t.Error("expected error for nil readers")
+	}
+}
+
+func TestNew_LexiconFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"lexicons/vader.txt": {Data: []byte("bad\t-2.5\t0.67\t[-2, -3, -3, -3, -3, -3, -2, -2, -1, -3]\n")},
+		"lexicons/emoji.txt": {Data: []byte("😡\tpouting face")},
+	}
+
+	sia, err := New(WithLexiconFS(fsys, "lexicons/vader.txt", "lexicons/emoji.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sia.lexicon) != 1 || sia.lexicon["bad"].Mean != -2.5 || sia.emojiLexicon["😡"] != "pouting face" {
+		t.Errorf("unexpected lexicons: %v %v", sia.lexicon, sia.emojiLexicon)
+	}
+
+	if _, err := New(WithLexiconFS(fsys, "lexicons/missing.txt", "lexicons/emoji.txt")); err == nil {
+		t.Error("expected error for missing lexicon")
+	}
+}
+
+func TestNew_ParseMode(t *testing.T) {
+	lexicon := "good\t1.9\t0.9\t[2, 2, 2, 2, 2, 2, 2, 2, 2, 1]\nbad\tnot-a-number\nugly\n\n"
+	emojiLexicon := "😁\tbeaming face\n😡\n"
+
+	_, err := New(WithLexiconFS(fstest.MapFS{"vader.txt": {Data: []byte(lexicon)}, "emoji.txt": {Data: []byte(emojiLexicon)}}, "vader.txt", "emoji.txt"))
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected parse error, got %v", err)
+	}
+	if parseErr.Name != "vader.txt" || parseErr.Line != 2 || parseErr.Content != "bad\tnot-a-number" {
+		t.Errorf("unexpected parse error: %v", parseErr)
+	}
+
+	sia, err := New(WithParseMode(Lenient), WithLexiconReaders(strings.NewReader(lexicon), strings.NewReader(emojiLexicon)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sia.lexicon) != 1 || len(sia.emojiLexicon) != 1 {
+		t.Errorf("unexpected lexicons: %v %v", sia.lexicon, sia.emojiLexicon)
+	}
+
+	var lines []string
+	for _, skipped := range sia.SkippedLines() {
+		lines = append(lines, fmt.Sprintf("%s:%d", skipped.Name, skipped.Line))
+	}
+	if strings.Join(lines, " ") != "lexicon:2 lexicon:3 emoji lexicon:2" {
+		t.Errorf("unexpected skipped lines: %v", lines)
+	}
+}
+
+func TestNew_Options(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sia.Options() != DefaultOptions() {
+		t.Errorf("expected default options, got %+v", sia.Options())
+	}
+
+	options := DefaultOptions()
+	options.EPIncr = 0
+	options.ButAfter = 1
+	tuned, err := New(WithOptions(options))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tuned.PolarityScores("VADER is smart!!!") != tuned.PolarityScores("VADER is smart.") {
+		t.Error("exclamation points changed score with zero EPIncr")
+	}
+	if tuned.PolarityScores("but VADER is smart").Compound != sia.PolarityScores("VADER is smart").Compound {
+		t.Error("but changed score with ButAfter equal to 1")
+	}
+	if sia.PolarityScores("VADER is smart!!!") == sia.PolarityScores("VADER is smart.") {
+		t.Error("default options ignored exclamation points")
+	}
+
+	invalid := []func(o *Options){
+		func(o *Options) { o.BIncr = -1 },
+		func(o *Options) { o.NScalar = 0.5 },
+		func(o *Options) { o.Alpha = 0 },
+		func(o *Options) { o.MaxQM = 0 },
+		func(o *Options) { o.BoosterDecay[1] = 1.5 },
+	}
+	for i, modify := range invalid {
+		options := DefaultOptions()
+		modify(&options)
+		if _, err := New(WithOptions(options)); err == nil {
+			t.Errorf("expected error for invalid options %d: %+v", i, options)
+		}
+		if _, err := sia.With(WithOptions(options)); err == nil {
+			t.Errorf("expected error for invalid derived options %d: %+v", i, options)
+		}
+	}
+}
+
+func TestAnalyzer_PolarityScores_Emoticons(t *testing.T) {
+	sia, err := New()
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -24,9 +149,60 @@ func TestSentimentIntensityAnalyzer_PolarityScores_Emoticons(t *testing.T) {
 	fmt.Printf("%s:%+v", sentence, sia.PolarityScores(sentence))
 }
 
-func TestSentimentIntensityAnalyzer_PolarityScores(t *testing.T) {
-	sia := SentimentIntensityAnalyzer{}
-	err := sia.Init()
+func TestAnalyzer_PolarityScores_LetterEmoticons(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// scores of the reference implementation
+	expected := map[string]float64{
+		"Make sure you :) or :D today!":             0.8633,
+		"VADER is VERY SMART, handsome, and FUNNY.": 0.9227,
+	}
+	for sentence, compound := range expected {
+		if score := sia.PolarityScores(sentence); score.Compound != compound {
+			t.Errorf("unexpected compound score of %q: %v, expected %v", sentence, score.Compound, compound)
+		}
+	}
+
+	tokens := map[string]float64{
+		"Great :D!":   2.3,
+		"Great (-:O,": 1.5,
+		"Great :P":    1.4,
+		"Great :p":    1.0,
+		"Great XD":    2.8,
+	}
+	for sentence, valence := range tokens {
+		explanation := sia.ExplainPolarityScores(sentence)
+		if len(explanation.Tokens) != 2 || !explanation.Tokens[1].InLexicon || explanation.Tokens[1].LexiconValence != valence {
+			t.Errorf("emoticon of %q is not scored: %+v", sentence, explanation.Tokens)
+		}
+	}
+}
+
+func TestAnalyzer_PolarityScores_Negations(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// scores of the reference implementation, each negation is applied once
+	expected := map[string]float64{
+		"VADER is not smart, handsome, nor funny.": -0.7424,
+		"At least it isn't a horrible book.":       0.431,
+		"Not bad at all":                           0.431,
+		"The plot was good, but the characters are uncompelling and the dialog is not great.": -0.7042,
+	}
+	for sentence, compound := range expected {
+		if score := sia.PolarityScores(sentence); score.Compound != compound {
+			t.Errorf("unexpected compound score of %q: %v, expected %v", sentence, score.Compound, compound)
+		}
+	}
+}
+
+func TestAnalyzer_PolarityScores(t *testing.T) {
+	sia, err := New()
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -125,9 +301,8 @@ var sentences = []string{"VADER is smart, handsome, and funny.", // positive sen
 	"Not bad at all",                             // Capitalized negation
 }
 
-func TestSentimentIntensityAnalyzer_PolarityScores_SpecialCaseIdioms(t *testing.T) {
-	sia := SentimentIntensityAnalyzer{}
-	err := sia.Init()
+func TestAnalyzer_PolarityScores_SpecialCaseIdioms(t *testing.T) {
+	sia, err := New()
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -144,7 +319,7 @@ func TestSentimentIntensityAnalyzer_PolarityScores_SpecialCaseIdioms(t *testing.
 	}
 
 	for sentence, label := range specialCaseIdiomsSentences {
-		sentiment := sia.PolarityScores(sentence)["compound"]
+		sentiment := sia.PolarityScores(sentence).Compound
 		if (label == 1 && sentiment < 0.05) || (label == -1 && sentiment > -0.05) || (label == 0 && (sentiment < -0.05 || sentiment > 0.05)) {
 			t.Errorf("Wrong sentiment for sentence: %s :%f", sentence, sentiment)
 		} else {
@@ -153,9 +328,161 @@ func TestSentimentIntensityAnalyzer_PolarityScores_SpecialCaseIdioms(t *testing.
 	}
 }
 
-func BenchmarkSentimentIntensityAnalyzer_PolarityScores(b *testing.B) {
-	sia := &SentimentIntensityAnalyzer{}
-	err := sia.Init()
+func TestSentimentScores_Map(t *testing.T) {
+	scores := SentimentScores{Neg: 0, Neu: 0.254, Pos: 0.746, Compound: 0.8316}
+
+	encoded, err := json.Marshal(scores)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != `{"neg":0,"neu":0.254,"pos":0.746,"compound":0.8316}` {
+		t.Errorf("unexpected json: %s", encoded)
+	}
+
+	m := scores.Map()
+	if len(m) != 4 || m["neg"] != 0 || m["neu"] != 0.254 || m["pos"] != 0.746 || m["compound"] != 0.8316 {
+		t.Errorf("unexpected map: %v", m)
+	}
+}
+
+func TestAnalyzer_PolarityScores_SentimentLadenIdioms(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sentimentLadenIdiomsSentences = map[string]int{
+		"The company is in the red":                   -1,
+		"The company is in the black":                 1,
+		"I am under the weather today":                -1,
+		"I am not under the weather today":            1,
+		"Their new release doesn't cut the mustard":   -1,
+		"The team is really on the ball":              1,
+		"The red ball is under the table":             0,
+		"Thunder the weatherman came back from lunch": 0,
+	}
+
+	for sentence, label := range sentimentLadenIdiomsSentences {
+		sentiment := sia.PolarityScores(sentence).Compound
+		if (label == 1 && sentiment < 0.05) || (label == -1 && sentiment > -0.05) || (label == 0 && (sentiment < -0.05 || sentiment > 0.05)) {
+			t.Errorf("Wrong sentiment for sentence: %s :%f", sentence, sentiment)
+		}
+	}
+
+	// boosters strengthen idiom valence
+	if really, plain := sia.PolarityScores("really on the ball"), sia.PolarityScores("on the ball"); really.Compound <= plain.Compound {
+		t.Errorf("booster didn't increase idiom valence: %f <= %f", really.Compound, plain.Compound)
+	}
+
+	// idioms are configurable per analyzer
+	sia, err = sia.With(WithSentimentLadenIdiom("Over the  moon", 3), WithoutSentimentLadenIdioms("in the red"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sentiment := sia.PolarityScores("We are over the moon").Compound; sentiment < 0.05 {
+		t.Errorf("Wrong sentiment for custom idiom: %f", sentiment)
+	}
+	if sentiment := sia.PolarityScores("The company is in the red").Compound; sentiment != 0 {
+		t.Errorf("Wrong sentiment for removed idiom: %f", sentiment)
+	}
+}
+
+func TestAnalyzer_Tables(t *testing.T) {
+	second, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := second.With(
+		WithNegations("nary"),
+		WithBooster("super duper", B_INCR),
+		WithoutBoosters("very"),
+		WithSpecialCaseIdiom("sick beat", 2.5),
+		WithoutSpecialCaseIdioms("bad ass"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sentiment := first.PolarityScores("nary good").Compound; sentiment >= 0 {
+		t.Errorf("custom negation ignored: %f", sentiment)
+	}
+	if boosted := first.PolarityScores("super duper good").Compound; boosted <= first.PolarityScores("good").Compound {
+		t.Errorf("multi-word booster ignored: %f", boosted)
+	}
+	if first.PolarityScores("very good").Compound != first.PolarityScores("good").Compound {
+		t.Error("removed booster still applied")
+	}
+	if sentiment := first.PolarityScores("sick beat").Compound; sentiment < 0.05 {
+		t.Errorf("custom special case idiom ignored: %f", sentiment)
+	}
+	if sentiment := first.PolarityScores("bad ass").Compound; sentiment > 0 {
+		t.Errorf("removed special case idiom still applied: %f", sentiment)
+	}
+
+	// parent analyzer and package defaults are not affected
+	if sentiment := second.PolarityScores("nary good").Compound; sentiment <= 0 {
+		t.Errorf("negation leaked to other analyzer: %f", sentiment)
+	}
+	if second.PolarityScores("very good").Compound <= second.PolarityScores("good").Compound {
+		t.Error("booster removal leaked to other analyzer")
+	}
+	if _, ok := SpecialCaseIdioms["bad ass"]; !ok {
+		t.Error("special case idiom removed from package defaults")
+	}
+	if _, ok := second.SpecialCaseIdioms()["sick beat"]; ok {
+		t.Error("special case idiom leaked to other analyzer")
+	}
+	for _, negation := range second.Negations() {
+		if negation == "nary" {
+			t.Error("negation leaked to other analyzer")
+		}
+	}
+}
+
+func TestAnalyzer_ExplainPolarityScores(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, sentence := range append(sentences, "The plot was not very good, but the ending was GREAT!!") {
+		explanation := sia.ExplainPolarityScores(sentence)
+		if explanation.SentimentScores != sia.PolarityScores(sentence) {
+			t.Errorf("%s: explanation scores %+v differ from polarity scores", sentence, explanation.SentimentScores)
+		}
+	}
+
+	explanation := sia.ExplainPolarityScores("The plot was not very good, but the ending was GREAT!!")
+	if explanation.PunctuationEmphasis != 2*0.292 {
+		t.Errorf("unexpected punctuation emphasis: %f", explanation.PunctuationEmphasis)
+	}
+
+	if good := explanation.Tokens[5]; good.Token != "good" || !good.InLexicon || good.LexiconValence != 1.9 {
+		t.Errorf("unexpected explanation of 'good': %+v", good)
+	}
+
+	for _, token := range explanation.Tokens {
+		valence := token.LexiconValence
+		for _, rule := range token.Rules {
+			if rule.Before != valence {
+				t.Errorf("%s: rule %s starts from %f, expected %f", token.Token, rule.Rule, rule.Before, valence)
+			}
+			valence = rule.After
+		}
+
+		if valence != token.Valence {
+			t.Errorf("%s: rules end with %f, final valence is %f", token.Token, valence, token.Valence)
+		}
+	}
+
+	if rules := explanation.Tokens[5].Rules; rules[0].Rule != RuleBooster || rules[1].Rule != RuleNegation || rules[len(rules)-1].Rule != RuleBut {
+		t.Errorf("unexpected rules for 'good': %+v", rules)
+	}
+}
+
+func BenchmarkAnalyzer_PolarityScores(b *testing.B) {
+	sia, err := New()
 	if err != nil {
 		b.Fatal(err)
 	}
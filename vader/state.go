@@ -0,0 +1,586 @@
+package vader
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// layer name reported for tokens loaded by WithLexiconState
+const stateLayer = "lexicon state"
+
+// version of binary lexicon state format written by WriteBinary
+const lexiconStateVersion = 1
+
+var lexiconStateMagic = [4]byte{'V', 'D', 'R', 'L'}
+
+// LexiconState is full lexicon state of an analyzer: valence and emoji lexicons,
+// boosters, negations, idioms and grammar words of its language.
+// Analyzer options are not part of the state.
+// It can be saved to JSON, to TSV in the original VADER layout
+// and to compact binary format, and loaded back with WithLexiconState.
+type LexiconState struct {
+	Lexicon              []LexiconEntry     `json:"lexicon"`
+	EmojiLexicon         map[string]string  `json:"emoji_lexicon"`
+	Boosters             map[string]float64 `json:"boosters"`
+	Negations            []string           `json:"negations"`
+	SpecialCaseIdioms    map[string]float64 `json:"special_case_idioms"`
+	SentimentLadenIdioms map[string]float64 `json:"sentiment_laden_idioms"`
+
+	// Layers, Origins and Conflicts are lexicon layers of the analyzer, see LexiconLayers,
+	// LexiconSource and LexiconConflicts. State without layers is loaded as "lexicon state" layer.
+	Layers    []string          `json:"layers,omitempty"`
+	Origins   map[string]string `json:"origins,omitempty"`
+	Conflicts []LexiconConflict `json:"conflicts,omitempty"`
+
+	// EmojiValences is true if emoji are scored by valences of lexicon, see WithEmojiValences
+	EmojiValences bool `json:"emoji_valences,omitempty"`
+
+	// Language is language pack of the analyzer without lexicons, which are saved above.
+	// Language of analyzer is kept when state has no language.
+	Language *LanguagePack `json:"language,omitempty"`
+}
+
+// LexiconState returns copy of full lexicon state of the analyzer
+func (sia *Analyzer) LexiconState() *LexiconState {
+	emojiLexicon := make(map[string]string, len(sia.emojiLexicon))
+	for emoji, description := range sia.emojiLexicon {
+		emojiLexicon[emoji] = description
+	}
+
+	state := &LexiconState{
+		Lexicon:              sia.LexiconEntries(),
+		EmojiLexicon:         emojiLexicon,
+		Boosters:             sia.Boosters(),
+		Negations:            sia.Negations(),
+		SpecialCaseIdioms:    sia.SpecialCaseIdioms(),
+		SentimentLadenIdioms: sia.SentimentLadenIdioms(),
+		Layers:               sia.LexiconLayers(),
+		EmojiValences:        sia.emojiValences,
+		Language:             sia.language.grammar(),
+	}
+	if len(sia.lexiconConflicts) > 0 {
+		state.Conflicts = sia.LexiconConflicts()
+	}
+	if len(sia.lexiconOrigins) > 0 {
+		state.Origins = make(map[string]string, len(sia.lexiconOrigins))
+		for token, layer := range sia.lexiconOrigins {
+			state.Origins[token] = layer
+		}
+	}
+
+	return state
+}
+
+// WithLexiconState replaces lexicons and tables of the analyzer with state,
+// lexicons are not parsed from TSV when state is given to New
+func WithLexiconState(state *LexiconState) Option {
+	return func(b *builder) error {
+		if state == nil {
+			return errors.New("vader: nil lexicon state")
+		}
+
+		b.state = state
+		return nil
+	}
+}
+
+func (sia *Analyzer) loadState(state *LexiconState) {
+	sia.lexicon = make(map[string]LexiconEntry, len(state.Lexicon))
+	for _, entry := range state.Lexicon {
+		sia.lexicon[entry.Token] = entry.clone()
+	}
+
+	sia.emojiLexicon = make(map[string]string, len(state.EmojiLexicon))
+	for emoji, description := range state.EmojiLexicon {
+		sia.emojiLexicon[emoji] = description
+	}
+	sia.emojiAliases = emojiAliases(sia.emojiLexicon)
+
+	sia.negations = make(map[string]bool, len(state.Negations))
+	for _, negation := range state.Negations {
+		sia.negations[negation] = true
+	}
+
+	sia.boosters = copyTable(state.Boosters)
+	sia.specialCaseIdioms = copyTable(state.SpecialCaseIdioms)
+	sia.sentimentLadenIdioms = copyTable(state.SentimentLadenIdioms)
+
+	sia.skippedLines = nil
+	sia.lexiconLayers = []string{stateLayer}
+	if len(state.Layers) > 0 {
+		sia.lexiconLayers = append([]string(nil), state.Layers...)
+	}
+	sia.lexiconOrigins = nil
+	if len(state.Origins) > 0 {
+		sia.lexiconOrigins = make(map[string]string, len(state.Origins))
+		for token, layer := range state.Origins {
+			sia.lexiconOrigins[token] = layer
+		}
+	}
+	sia.lexiconConflicts = nil
+	for _, conflict := range state.Conflicts {
+		sia.lexiconConflicts = append(sia.lexiconConflicts, conflict.clone())
+	}
+	sia.emojiValences = state.EmojiValences
+	if state.Language != nil {
+		sia.language = state.Language.grammar()
+	}
+}
+
+// WriteTSV writes valence and emoji lexicons in the original VADER layout,
+// which can be loaded with WithLexiconReaders. Boosters, negations and idioms
+// have no TSV layout, use JSON or binary format to save them too.
+func (s *LexiconState) WriteTSV(lexicon, emojiLexicon io.Writer) error {
+	w := bufio.NewWriter(lexicon)
+	for _, entry := range s.Lexicon {
+		fmt.Fprintf(w, "%s\t%s\t%s", entry.Token, formatFloat(entry.Mean), formatFloat(entry.StdDev))
+		if len(entry.Ratings) > 0 {
+			ratings := make([]string, len(entry.Ratings))
+			for i, rating := range entry.Ratings {
+				ratings[i] = formatFloat(rating)
+			}
+			fmt.Fprintf(w, "\t[%s]", strings.Join(ratings, ", "))
+		}
+		w.WriteString("\n")
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	w = bufio.NewWriter(emojiLexicon)
+	for _, emoji := range sortedKeys(s.EmojiLexicon) {
+		fmt.Fprintf(w, "%s\t%s\n", emoji, s.EmojiLexicon[emoji])
+	}
+
+	return w.Flush()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedTableKeys(table map[string]float64) []string {
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// WriteBinary writes state in compact versioned binary format, which is read by ReadLexiconState.
+//
+// Format starts with magic "VDRL" and format version, followed by sections of
+// lexicon, emoji lexicon, boosters, negations, special case and sentiment laden idioms,
+// lexicon layers, origins of tokens, conflicts, emoji valences flag and language pack
+// without lexicons, which is preceded by flag of its presence.
+// Every section starts with number of items; strings are prefixed with length,
+// counts and lengths are unsigned varints and floats are little endian float64.
+// Raw ratings of an entry follow their count and kind: whole ratings from -128 to 127,
+// such as ratings of the default lexicon, are written as single bytes, other ratings as floats.
+func (s *LexiconState) WriteBinary(w io.Writer) error {
+	bw := &binaryWriter{w: bufio.NewWriter(w)}
+	bw.write(lexiconStateMagic[:])
+	bw.uvarint(lexiconStateVersion)
+
+	bw.uvarint(uint64(len(s.Lexicon)))
+	for _, entry := range s.Lexicon {
+		bw.entry(entry)
+	}
+
+	bw.uvarint(uint64(len(s.EmojiLexicon)))
+	for _, emoji := range sortedKeys(s.EmojiLexicon) {
+		bw.string(emoji)
+		bw.string(s.EmojiLexicon[emoji])
+	}
+
+	bw.table(s.Boosters)
+
+	bw.strings(s.Negations)
+
+	bw.table(s.SpecialCaseIdioms)
+	bw.table(s.SentimentLadenIdioms)
+
+	bw.uvarint(uint64(len(s.Layers)))
+	for _, layer := range s.Layers {
+		bw.string(layer)
+	}
+	bw.uvarint(uint64(len(s.Origins)))
+	for _, token := range sortedKeys(s.Origins) {
+		bw.string(token)
+		bw.string(s.Origins[token])
+	}
+	bw.uvarint(uint64(len(s.Conflicts)))
+	for _, conflict := range s.Conflicts {
+		bw.string(conflict.Token)
+		bw.string(conflict.Layer)
+		bw.string(conflict.PreviousLayer)
+		bw.entry(conflict.Previous)
+		bw.bool(conflict.Entry != nil)
+		if conflict.Entry != nil {
+			bw.entry(*conflict.Entry)
+		}
+	}
+	bw.bool(s.EmojiValences)
+	bw.bool(s.Language != nil)
+	if s.Language != nil {
+		bw.language(s.Language)
+	}
+
+	if bw.err != nil {
+		return bw.err
+	}
+	return bw.w.Flush()
+}
+
+// ReadLexiconState reads state written by WriteBinary
+func ReadLexiconState(r io.Reader) (*LexiconState, error) {
+	br := &binaryReader{r: bufio.NewReader(r)}
+
+	var magic [4]byte
+	br.read(magic[:])
+	if br.err == nil && magic != lexiconStateMagic {
+		return nil, errors.New("vader: not a binary lexicon state")
+	}
+	version := br.uvarint()
+	if br.err == nil && version != lexiconStateVersion {
+		return nil, fmt.Errorf("vader: unsupported lexicon state version %d", version)
+	}
+
+	s := &LexiconState{}
+
+	n := br.count()
+	s.Lexicon = make([]LexiconEntry, 0, n)
+	for i := 0; i < n && br.err == nil; i++ {
+		s.Lexicon = append(s.Lexicon, br.entry())
+	}
+
+	n = br.count()
+	s.EmojiLexicon = make(map[string]string, n)
+	for i := 0; i < n && br.err == nil; i++ {
+		emoji := br.string()
+		s.EmojiLexicon[emoji] = br.string()
+	}
+
+	s.Boosters = br.table()
+
+	s.Negations = br.strings()
+
+	s.SpecialCaseIdioms = br.table()
+	s.SentimentLadenIdioms = br.table()
+
+	n = br.count()
+	for i := 0; i < n && br.err == nil; i++ {
+		s.Layers = append(s.Layers, br.string())
+	}
+	if n = br.count(); n > 0 {
+		s.Origins = make(map[string]string, n)
+	}
+	for i := 0; i < n && br.err == nil; i++ {
+		token := br.string()
+		s.Origins[token] = br.string()
+	}
+	n = br.count()
+	for i := 0; i < n && br.err == nil; i++ {
+		conflict := LexiconConflict{Token: br.string(), Layer: br.string(), PreviousLayer: br.string(), Previous: br.entry()}
+		if br.bool() {
+			entry := br.entry()
+			conflict.Entry = &entry
+		}
+		s.Conflicts = append(s.Conflicts, conflict)
+	}
+	s.EmojiValences = br.bool()
+	if br.bool() {
+		s.Language = br.language()
+	}
+
+	if br.err != nil {
+		if br.err == io.EOF {
+			br.err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("vader: invalid binary lexicon state: %w", br.err)
+	}
+
+	return s, nil
+}
+
+// binaryWriter writes binary lexicon state values and keeps the first error
+type binaryWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (bw *binaryWriter) write(p []byte) {
+	if bw.err == nil {
+		_, bw.err = bw.w.Write(p)
+	}
+}
+
+func (bw *binaryWriter) uvarint(x uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	bw.write(buf[:binary.PutUvarint(buf[:], x)])
+}
+
+func (bw *binaryWriter) float(f float64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], math.Float64bits(f))
+	bw.write(buf[:])
+}
+
+func (bw *binaryWriter) string(s string) {
+	bw.uvarint(uint64(len(s)))
+	bw.write([]byte(s))
+}
+
+func (bw *binaryWriter) bool(b bool) {
+	if b {
+		bw.uvarint(1)
+	} else {
+		bw.uvarint(0)
+	}
+}
+
+func (bw *binaryWriter) entry(entry LexiconEntry) {
+	bw.string(entry.Token)
+	bw.float(entry.Mean)
+	bw.float(entry.StdDev)
+	bw.uvarint(uint64(len(entry.Ratings)))
+	if len(entry.Ratings) == 0 {
+		return
+	}
+
+	if wholeRatings(entry.Ratings) {
+		bw.uvarint(ratingsInt8)
+		for _, rating := range entry.Ratings {
+			bw.write([]byte{byte(int8(rating))})
+		}
+	} else {
+		bw.uvarint(ratingsFloat)
+		for _, rating := range entry.Ratings {
+			bw.float(rating)
+		}
+	}
+}
+
+// kinds of raw ratings in binary lexicon state
+const (
+	ratingsInt8 = iota
+	ratingsFloat
+)
+
+// check whether all ratings fit into int8 without loss
+func wholeRatings(ratings []float64) bool {
+	for _, rating := range ratings {
+		if rating != math.Trunc(rating) || rating < math.MinInt8 || rating > math.MaxInt8 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (bw *binaryWriter) strings(list []string) {
+	bw.uvarint(uint64(len(list)))
+	for _, s := range list {
+		bw.string(s)
+	}
+}
+
+func (bw *binaryWriter) wordLists(lists map[string][]string) {
+	keys := make([]string, 0, len(lists))
+	for key := range lists {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	bw.uvarint(uint64(len(lists)))
+	for _, key := range keys {
+		bw.string(key)
+		bw.strings(lists[key])
+	}
+}
+
+func (bw *binaryWriter) language(pack *LanguagePack) {
+	bw.string(pack.Code)
+	bw.strings(pack.Negations)
+	bw.table(pack.Boosters)
+	bw.table(pack.SpecialCaseIdioms)
+	bw.table(pack.SentimentLadenIdioms)
+	bw.strings(pack.ContrastiveConjunctions)
+	bw.strings(pack.NeutralPhrases)
+	bw.wordLists(pack.ConditionalNegations)
+	bw.wordLists(pack.EmphaticNegations)
+	bw.strings(pack.NegationCancellers)
+	bw.string(pack.NegationSuffix)
+	bw.string(pack.NegativeDeterminer)
+	bw.strings(pack.Disjunctions)
+}
+
+func (bw *binaryWriter) table(table map[string]float64) {
+	bw.uvarint(uint64(len(table)))
+	for _, key := range sortedTableKeys(table) {
+		bw.string(key)
+		bw.float(table[key])
+	}
+}
+
+// binaryReader reads binary lexicon state values and keeps the first error,
+// values read after an error are zero
+type binaryReader struct {
+	r   *bufio.Reader
+	err error
+	// scratch buffer reused by reads of strings and ratings
+	buf []byte
+}
+
+// limit of a count or string length, guards allocations on corrupted input
+const maxBinaryCount = 1 << 24
+
+func (br *binaryReader) read(p []byte) {
+	if br.err == nil {
+		_, br.err = io.ReadFull(br.r, p)
+	}
+}
+
+func (br *binaryReader) uvarint() uint64 {
+	if br.err != nil {
+		return 0
+	}
+
+	var x uint64
+	x, br.err = binary.ReadUvarint(br.r)
+	return x
+}
+
+func (br *binaryReader) count() int {
+	n := br.uvarint()
+	if n > maxBinaryCount {
+		if br.err == nil {
+			br.err = fmt.Errorf("count %d is too large", n)
+		}
+		return 0
+	}
+
+	return int(n)
+}
+
+func (br *binaryReader) float() float64 {
+	var buf [8]byte
+	br.read(buf[:])
+	return math.Float64frombits(binary.LittleEndian.Uint64(buf[:]))
+}
+
+func (br *binaryReader) string() string {
+	buf := br.scratch(br.count())
+	br.read(buf)
+	return string(buf)
+}
+
+// scratch buffer of n bytes, valid until the next call
+func (br *binaryReader) scratch(n int) []byte {
+	if cap(br.buf) < n {
+		br.buf = make([]byte, n)
+	}
+
+	return br.buf[:n]
+}
+
+func (br *binaryReader) bool() bool {
+	return br.uvarint() == 1
+}
+
+func (br *binaryReader) entry() LexiconEntry {
+	entry := LexiconEntry{Token: br.string(), Mean: br.float(), StdDev: br.float()}
+	ratings := br.count()
+	if ratings == 0 || br.err != nil {
+		return entry
+	}
+
+	entry.Ratings = make([]float64, ratings)
+	switch kind := br.uvarint(); kind {
+	case ratingsInt8:
+		buf := br.scratch(ratings)
+		br.read(buf)
+		for j, b := range buf {
+			entry.Ratings[j] = float64(int8(b))
+		}
+	case ratingsFloat:
+		for j := range entry.Ratings {
+			entry.Ratings[j] = br.float()
+		}
+	default:
+		if br.err == nil {
+			br.err = fmt.Errorf("unknown kind %d of ratings", kind)
+		}
+	}
+
+	return entry
+}
+
+func (br *binaryReader) table() map[string]float64 {
+	n := br.count()
+	table := make(map[string]float64, n)
+	for i := 0; i < n && br.err == nil; i++ {
+		key := br.string()
+		table[key] = br.float()
+	}
+
+	return table
+}
+
+func (br *binaryReader) strings() []string {
+	n := br.count()
+	list := make([]string, 0, n)
+	for i := 0; i < n && br.err == nil; i++ {
+		list = append(list, br.string())
+	}
+
+	return list
+}
+
+func (br *binaryReader) wordLists() map[string][]string {
+	n := br.count()
+	lists := make(map[string][]string, n)
+	for i := 0; i < n && br.err == nil; i++ {
+		key := br.string()
+		lists[key] = br.strings()
+	}
+
+	return lists
+}
+
+func (br *binaryReader) language() *LanguagePack {
+	return &LanguagePack{
+		Code:                    br.string(),
+		Negations:               br.strings(),
+		Boosters:                br.table(),
+		SpecialCaseIdioms:       br.table(),
+		SentimentLadenIdioms:    br.table(),
+		ContrastiveConjunctions: br.strings(),
+		NeutralPhrases:          br.strings(),
+		ConditionalNegations:    br.wordLists(),
+		EmphaticNegations:       br.wordLists(),
+		NegationCancellers:      br.strings(),
+		NegationSuffix:          br.string(),
+		NegativeDeterminer:      br.string(),
+		Disjunctions:            br.strings(),
+	}
+}
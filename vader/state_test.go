@@ -0,0 +1,274 @@
+package vader
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/drankou/go-vader/data"
+)
+
+// analyzer with edited lexicons and tables
+func editedAnalyzer(t *testing.T) *Analyzer {
+	sia, err := New(
+		WithLexiconTransform(func(entry LexiconEntry) (LexiconEntry, bool) {
+			if entry.Token == "sick" {
+				entry.Mean = 2.5
+			}
+			return entry, true
+		}),
+		WithNegations("nary"),
+		WithBooster("hella", 0.4),
+		WithSpecialCaseIdiom("on fire", 3),
+		WithSentimentLadenIdiom("over the moon", 3.2),
+		WithLexiconLayers(LexiconLayer{Name: "slang", Entries: []LexiconEntry{{Token: "dope", Mean: 2.1}}, Neutralized: []string{"killer"}}),
+		WithEmojiValences(DefaultEmojiValences()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sia
+}
+
+func TestLexiconState_RoundTrip(t *testing.T) {
+	sia := editedAnalyzer(t)
+	state := sia.LexiconState()
+
+	var buf bytes.Buffer
+	if err := state.WriteBinary(&buf); err != nil {
+		t.Fatal(err)
+	}
+	fromBinary, err := ReadLexiconState(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromBinary, state) {
+		t.Error("binary round trip changed lexicon state")
+	}
+
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON := &LexiconState{}
+	if err := json.Unmarshal(encoded, fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromJSON, state) {
+		t.Error("JSON round trip changed lexicon state")
+	}
+
+	loaded, err := New(WithLexiconState(fromBinary))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.LexiconState(), state) {
+		t.Error("analyzer loaded from state has different state")
+	}
+	if layer, _ := loaded.LexiconSource("dope"); layer != "slang" || !reflect.DeepEqual(loaded.LexiconLayers(), sia.LexiconLayers()) {
+		t.Errorf("lexicon layers not restored: %q, %q", layer, loaded.LexiconLayers())
+	}
+	if conflicts := loaded.LexiconConflicts(); len(conflicts) != 1 || !reflect.DeepEqual(conflicts, sia.LexiconConflicts()) {
+		t.Errorf("lexicon conflicts not restored: %+v", conflicts)
+	}
+	if !loaded.emojiValences {
+		t.Error("emoji valences not restored")
+	}
+	for _, sentence := range append(sentences, "That trick was sick", "nary a good idea", "hella good", "I am over the moon", "dope killer app", "😡 horrible", "Loved it 😍👍🏽") {
+		if loaded.PolarityScores(sentence) != sia.PolarityScores(sentence) {
+			t.Errorf("different scores of loaded analyzer for %q", sentence)
+		}
+	}
+}
+
+func TestLexiconState_RoundTrip_Language(t *testing.T) {
+	sia, err := New(WithLanguage(Spanish()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := sia.LexiconState()
+	if state.Language == nil || state.Language.Lexicon != "" {
+		t.Fatalf("unexpected language of state: %+v", state.Language)
+	}
+
+	var buf bytes.Buffer
+	if err := state.WriteBinary(&buf); err != nil {
+		t.Fatal(err)
+	}
+	fromBinary, err := ReadLexiconState(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromBinary, state) {
+		t.Error("binary round trip changed lexicon state")
+	}
+
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON := &LexiconState{}
+	if err := json.Unmarshal(encoded, fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromJSON, state) {
+		t.Error("JSON round trip changed lexicon state")
+	}
+
+	for _, loadedState := range []*LexiconState{fromBinary, fromJSON} {
+		loaded, err := New(WithLexiconState(loadedState))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if loaded.Language() != "es" {
+			t.Errorf("unexpected language of loaded analyzer: %q", loaded.Language())
+		}
+		for _, sentence := range []string{"Bueno pero malo", "Nunca tan feliz", "Sin duda excelente", "No es bueno"} {
+			if loaded.PolarityScores(sentence) != sia.PolarityScores(sentence) {
+				t.Errorf("different scores of loaded analyzer for %q", sentence)
+			}
+		}
+	}
+}
+
+func TestLexiconState_WriteTSV(t *testing.T) {
+	state := editedAnalyzer(t).LexiconState()
+
+	var lexicon, emojiLexicon bytes.Buffer
+	if err := state.WriteTSV(&lexicon, &emojiLexicon); err != nil {
+		t.Fatal(err)
+	}
+
+	sia, err := New(WithLexiconReaders(&lexicon, &emojiLexicon))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sia.LexiconEntries(), state.Lexicon) {
+		t.Error("TSV round trip changed lexicon")
+	}
+	if !reflect.DeepEqual(sia.LexiconState().EmojiLexicon, state.EmojiLexicon) {
+		t.Error("TSV round trip changed emoji lexicon")
+	}
+}
+
+func TestReadLexiconState_Invalid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&LexiconState{Lexicon: []LexiconEntry{{Token: "good", Mean: 1.9}}}).WriteBinary(&buf); err != nil {
+		t.Fatal(err)
+	}
+	encoded := buf.Bytes()
+
+	inputs := map[string][]byte{
+		"empty":     nil,
+		"magic":     []byte("VADER lexicon"),
+		"version":   append([]byte("VDRL"), lexiconStateVersion+1),
+		"truncated": encoded[:len(encoded)-3],
+	}
+	for name, input := range inputs {
+		if _, err := ReadLexiconState(bytes.NewReader(input)); err == nil {
+			t.Errorf("expected error for %s input", name)
+		}
+	}
+}
+
+func TestLexiconState_WithoutLayers(t *testing.T) {
+	state := &LexiconState{Lexicon: []LexiconEntry{{Token: "good", Mean: 1.9}}, Negations: []string{"not"}}
+	sia, err := New(WithLexiconState(state))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if layer, _ := sia.LexiconSource("good"); layer != "lexicon state" {
+		t.Errorf("unexpected layer of state without layers: %q", layer)
+	}
+	if sia.Language() != "en" {
+		t.Errorf("unexpected language of state without language: %q", sia.Language())
+	}
+}
+
+func TestLexiconState_WriteBinary_Ratings(t *testing.T) {
+	state := &LexiconState{Lexicon: []LexiconEntry{
+		{Token: "good", Mean: 1.9, StdDev: 0.9434, Ratings: []float64{2, 1, 1, 3, 2, 4, 2, 2, 1, 1}},
+		{Token: "fine", Mean: 0.8, StdDev: 0.4, Ratings: []float64{0.5, 1, 1.5}},
+		{Token: "huge", Mean: 3, StdDev: 0, Ratings: []float64{300, -200}},
+		{Token: "meh", Mean: -0.5},
+	}}
+
+	var buf bytes.Buffer
+	if err := state.WriteBinary(&buf); err != nil {
+		t.Fatal(err)
+	}
+	fromBinary, err := ReadLexiconState(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromBinary.Lexicon, state.Lexicon) {
+		t.Errorf("binary round trip changed ratings: %+v", fromBinary.Lexicon)
+	}
+}
+
+func TestLexiconState_WriteBinary_Size(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := sia.LexiconState().WriteBinary(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if size, tsvSize := buf.Len(), len(data.VaderLexicon)+len(data.EmojiLexicon); size >= tsvSize {
+		t.Errorf("binary lexicon state of %d bytes is not smaller than TSV lexicons of %d bytes", size, tsvSize)
+	}
+}
+
+func BenchmarkReadLexiconState(b *testing.B) {
+	sia, err := New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := sia.LexiconState().WriteBinary(&buf); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ReadLexiconState(bytes.NewReader(buf.Bytes())); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := New(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNew_LexiconState(b *testing.B) {
+	sia, err := New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := sia.LexiconState().WriteBinary(&buf); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		state, err := ReadLexiconState(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := New(WithLexiconState(state)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
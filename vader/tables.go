@@ -0,0 +1,186 @@
+package vader
+
+import (
+	"sort"
+	"strings"
+)
+
+// copy tables of analyzer language to analyzer,
+// default booster increment and decrement are replaced by analyzer options
+func (sia *Analyzer) initTables() {
+	sia.negations = make(map[string]bool, len(sia.language.Negations))
+	for _, negation := range sia.language.Negations {
+		sia.negations[negation] = true
+	}
+
+	sia.boosters = make(map[string]float64, len(sia.language.Boosters))
+	for booster, value := range sia.language.Boosters {
+		switch value {
+		case B_INCR:
+			value = sia.options.BIncr
+		case B_DECR:
+			value = sia.options.BDecr
+		}
+		sia.boosters[booster] = value
+	}
+
+	sia.specialCaseIdioms = copyTable(sia.language.SpecialCaseIdioms)
+	sia.sentimentLadenIdioms = copyTable(sia.language.SentimentLadenIdioms)
+}
+
+// default boosters which kept previous increment or decrement get values of current options
+func (sia *Analyzer) rescaleBoosters(previous Options) {
+	for booster, value := range sia.boosters {
+		if _, ok := sia.language.Boosters[booster]; !ok {
+			continue
+		}
+
+		switch value {
+		case previous.BIncr:
+			sia.boosters[booster] = sia.options.BIncr
+		case previous.BDecr:
+			sia.boosters[booster] = sia.options.BDecr
+		}
+	}
+}
+
+// normalize phrase to lower case words separated by single space, as tokens are matched
+func normalizePhrase(phrase string) string {
+	return strings.Join(strings.Fields(strings.ToLower(phrase)), " ")
+}
+
+func (sia *Analyzer) isNegation(word string) bool {
+	return sia.negations[word]
+}
+
+// Negations returns sorted negation words of the analyzer
+func (sia *Analyzer) Negations() []string {
+	negations := make([]string, 0, len(sia.negations))
+	for negation := range sia.negations {
+		negations = append(negations, negation)
+	}
+	sort.Strings(negations)
+
+	return negations
+}
+
+// Boosters returns copy of booster and dampener table of the analyzer
+func (sia *Analyzer) Boosters() map[string]float64 {
+	return copyTable(sia.boosters)
+}
+
+// SpecialCaseIdioms returns copy of special case idioms table of the analyzer
+func (sia *Analyzer) SpecialCaseIdioms() map[string]float64 {
+	return copyTable(sia.specialCaseIdioms)
+}
+
+// SentimentLadenIdioms returns copy of sentiment laden idioms table of the analyzer
+func (sia *Analyzer) SentimentLadenIdioms() map[string]float64 {
+	return copyTable(sia.sentimentLadenIdioms)
+}
+
+// WithNegations adds negation words to the analyzer
+func WithNegations(words ...string) Option {
+	return edit(func(sia *Analyzer) {
+		for _, word := range words {
+			sia.negations[normalizePhrase(word)] = true
+		}
+	})
+}
+
+// WithoutNegations removes negation words from the analyzer
+func WithoutNegations(words ...string) Option {
+	return edit(func(sia *Analyzer) {
+		for _, word := range words {
+			delete(sia.negations, normalizePhrase(word))
+		}
+	})
+}
+
+// WithBooster adds booster (positive value) or dampener (negative value) to the analyzer,
+// multi-word boosters such as "sort of" are supported up to three words
+func WithBooster(booster string, value float64) Option {
+	return edit(func(sia *Analyzer) {
+		sia.boosters[normalizePhrase(booster)] = value
+	})
+}
+
+// WithoutBoosters removes boosters and dampeners from the analyzer
+func WithoutBoosters(boosters ...string) Option {
+	return edit(func(sia *Analyzer) {
+		for _, booster := range boosters {
+			delete(sia.boosters, normalizePhrase(booster))
+		}
+	})
+}
+
+// WithSpecialCaseIdiom adds idiom containing lexicon words, e.g. "bad ass", which valence replaces valence of its words.
+// Idioms up to three words are supported.
+func WithSpecialCaseIdiom(idiom string, valence float64) Option {
+	return edit(func(sia *Analyzer) {
+		sia.specialCaseIdioms[normalizePhrase(idiom)] = valence
+	})
+}
+
+// WithoutSpecialCaseIdioms removes special case idioms from the analyzer
+func WithoutSpecialCaseIdioms(idioms ...string) Option {
+	return edit(func(sia *Analyzer) {
+		for _, idiom := range idioms {
+			delete(sia.specialCaseIdioms, normalizePhrase(idiom))
+		}
+	})
+}
+
+// WithSentimentLadenIdiom adds multi-word idiom that carries sentiment without containing a lexicon word,
+// e.g. "under the weather"
+func WithSentimentLadenIdiom(idiom string, valence float64) Option {
+	return edit(func(sia *Analyzer) {
+		sia.sentimentLadenIdioms[normalizePhrase(idiom)] = valence
+	})
+}
+
+// WithoutSentimentLadenIdioms removes sentiment laden idioms from the analyzer
+func WithoutSentimentLadenIdioms(idioms ...string) Option {
+	return edit(func(sia *Analyzer) {
+		for _, idiom := range idioms {
+			delete(sia.sentimentLadenIdioms, normalizePhrase(idiom))
+		}
+	})
+}
+
+func copyTable(table map[string]float64) map[string]float64 {
+	copied := make(map[string]float64, len(table))
+	for key, value := range table {
+		copied[key] = value
+	}
+
+	return copied
+}
+
+// sentiment laden idiom split to words
+type ladenIdiom struct {
+	words   []string
+	valence float64
+}
+
+// split sentiment laden idioms to words and sort them, so that longer idioms
+// are preferred in case of overlap
+func (sia *Analyzer) sortLadenIdioms() {
+	idioms := make([]string, 0, len(sia.sentimentLadenIdioms))
+	for idiom := range sia.sentimentLadenIdioms {
+		idioms = append(idioms, idiom)
+	}
+	sort.Slice(idioms, func(i, j int) bool {
+		if len(idioms[i]) != len(idioms[j]) {
+			return len(idioms[i]) > len(idioms[j])
+		}
+		return idioms[i] < idioms[j]
+	})
+
+	sia.sortedLadenIdioms = make([]ladenIdiom, 0, len(idioms))
+	for _, idiom := range idioms {
+		if words := strings.Fields(idiom); len(words) > 0 {
+			sia.sortedLadenIdioms = append(sia.sortedLadenIdioms, ladenIdiom{words, sia.sentimentLadenIdioms[idiom]})
+		}
+	}
+}
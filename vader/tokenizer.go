@@ -0,0 +1,89 @@
+package vader
+
+import (
+	"errors"
+	"strings"
+)
+
+// Tokenizer splits text into words and emoticons scored by the analyzer.
+// Analyzer passes text after percentages are replaced with placeholders and emoji
+// with their descriptions; tokens are looked up in lexicons in lower case.
+type Tokenizer interface {
+	Tokenize(text string) []string
+}
+
+// TokenizerFunc is a function used as Tokenizer
+type TokenizerFunc func(text string) []string
+
+// Tokenize calls f(text)
+func (f TokenizerFunc) Tokenize(text string) []string {
+	return f(text)
+}
+
+// WhitespaceTokenizer splits text on whitespace and removes leading and trailing
+// punctuation of words with CleanWordsAndEmoticons. Unlike the default tokenizer
+// of analyzer, it doesn't know lexicon emoticons, such as ":D" of ":D!".
+type WhitespaceTokenizer struct{}
+
+// Tokenize returns CleanWordsAndEmoticons(text)
+func (WhitespaceTokenizer) Tokenize(text string) []string {
+	return CleanWordsAndEmoticons(text)
+}
+
+// DefaultTokenizer returns tokenizer analyzer uses by default, which splits text as
+// WhitespaceTokenizer does, but keeps emoticons of the analyzer lexicon whole.
+// Custom tokenizers can wrap it; it keeps using lexicon of sia in derived analyzers.
+func (sia *Analyzer) DefaultTokenizer() Tokenizer {
+	return TokenizerFunc(func(text string) []string {
+		return cleanWordsAndEmoticons(text, sia.isEmoticon)
+	})
+}
+
+// WithTokenizer sets tokenizer of the analyzer, see DefaultTokenizer for the default one
+func WithTokenizer(tokenizer Tokenizer) Option {
+	return func(b *builder) error {
+		if tokenizer == nil {
+			return errors.New("vader: nil tokenizer")
+		}
+
+		b.edits = append(b.edits, func(sia *Analyzer) {
+			sia.tokenizer = tokenizer
+		})
+		return nil
+	}
+}
+
+// split text with analyzer tokenizer
+func (sia *Analyzer) tokenize(text string) []string {
+	if sia.tokenizer == nil {
+		return cleanWordsAndEmoticons(text, sia.isEmoticon)
+	}
+
+	return sia.tokenizer.Tokenize(text)
+}
+
+// tokens of text, lexicon emoticons are looked up in their original case, e.g. ":P" and ":p"
+func (sia *Analyzer) sentiText(text string) *SentiText {
+	sentiText := NewSentiTextFromTokens(sia.tokenize(text))
+	for i, token := range sentiText.WordsAndEmoticons {
+		if token != sentiText.WordsAndEmoticonsLower[i] && sia.isEmoticon(token) {
+			if _, ok := sia.lexicon[token]; ok {
+				sentiText.WordsAndEmoticonsLower[i] = token
+			}
+		}
+	}
+
+	return sentiText
+}
+
+// check whether token is an emoticon of the lexicon, i.e. lexicon token with punctuation
+func (sia *Analyzer) isEmoticon(token string) bool {
+	if strings.IndexFunc(token, isPunctuation) < 0 {
+		return false
+	}
+	if _, ok := sia.lexicon[token]; ok {
+		return true
+	}
+	_, ok := sia.lexicon[strings.ToLower(token)]
+	return ok
+}
@@ -0,0 +1,91 @@
+package vader
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestWhitespaceTokenizer(t *testing.T) {
+	text := "VADER is smart, handsome, and funny! :)"
+	if tokens := (WhitespaceTokenizer{}).Tokenize(text); !reflect.DeepEqual(tokens, CleanWordsAndEmoticons(text)) {
+		t.Errorf("unexpected tokens: %q", tokens)
+	}
+}
+
+func TestAnalyzer_DefaultTokenizer(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	explicit, err := New(WithTokenizer(sia.DefaultTokenizer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	whitespace, err := New(WithTokenizer(WhitespaceTokenizer{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tokens := sia.DefaultTokenizer().Tokenize("Great :D!"); !reflect.DeepEqual(tokens, []string{"Great", ":D"}) {
+		t.Errorf("unexpected tokens: %q", tokens)
+	}
+	for _, text := range append(sentences, "Great :D!") {
+		if explicit.PolarityScores(text) != sia.PolarityScores(text) {
+			t.Errorf("different scores of explicit default tokenizer for %q", text)
+		}
+	}
+	if whitespace.PolarityScores("Great :D!") == sia.PolarityScores("Great :D!") {
+		t.Error("whitespace tokenizer kept emoticon")
+	}
+}
+
+func TestWithTokenizer(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// hashtag words separated by underscores
+	underscores, err := sia.With(WithTokenizer(TokenizerFunc(func(text string) []string {
+		return strings.FieldsFunc(text, func(r rune) bool {
+			return r == '_' || r == ' '
+		})
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if underscores.PolarityScores("very_good_movie") != sia.PolarityScores("very good movie") {
+		t.Error("custom tokenizer is not used")
+	}
+	if sia.PolarityScores("very_good_movie").Compound != 0 {
+		t.Error("original analyzer uses custom tokenizer")
+	}
+
+	// URLs kept whole, so that words in them are not scored
+	url := regexp.MustCompile(`^https?://`)
+	base := sia.DefaultTokenizer()
+	urls, err := New(WithTokenizer(TokenizerFunc(func(text string) []string {
+		var tokens []string
+		for _, field := range strings.Fields(text) {
+			if url.MatchString(field) {
+				tokens = append(tokens, field)
+			} else {
+				tokens = append(tokens, base.Tokenize(field)...)
+			}
+		}
+		return tokens
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	explanation := urls.ExplainPolarityScores("Read https://example.com/best")
+	if len(explanation.Tokens) != 2 || explanation.Tokens[1].Token != "https://example.com/best" {
+		t.Errorf("unexpected tokens: %+v", explanation.Tokens)
+	}
+
+	if _, err := New(WithTokenizer(nil)); err == nil {
+		t.Error("expected error for nil tokenizer")
+	}
+}
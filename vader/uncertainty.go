@@ -0,0 +1,115 @@
+package vader
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/gonum/floats"
+)
+
+// UncertaintyOptions configure bootstrap estimation of score intervals
+type UncertaintyOptions struct {
+	// Samples is number of bootstrap samples, 1000 if not positive
+	Samples int
+	// Confidence level of intervals, 0.95 if not between 0 and 1
+	Confidence float64
+	// Seed of random generator, intervals are reproducible for the same seed
+	Seed int64
+}
+
+// Interval is a confidence interval of a score
+type Interval struct {
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+}
+
+// Contains reports whether value lies within interval
+func (i Interval) Contains(value float64) bool {
+	return value >= i.Low && value <= i.High
+}
+
+// UncertainScores are polarity scores with confidence intervals
+// derived from distributions of raw ratings of matched lexicon words
+type UncertainScores struct {
+	SentimentScores
+	NegInterval      Interval `json:"neg_interval"`
+	NeuInterval      Interval `json:"neu_interval"`
+	PosInterval      Interval `json:"pos_interval"`
+	CompoundInterval Interval `json:"compound_interval"`
+}
+
+// PolarityScoresWithUncertainty returns polarity scores with confidence intervals.
+// Intervals are estimated by bootstrap: in each sample valence of every matched lexicon word
+// is replaced by mean of its raw ratings resampled with replacement and the text is scored again.
+// Words without raw ratings keep their mean valence.
+func (sia *Analyzer) PolarityScoresWithUncertainty(text string, opts UncertaintyOptions) UncertainScores {
+	samples := opts.Samples
+	if samples <= 0 {
+		samples = 1000
+	}
+	confidence := opts.Confidence
+	if !(confidence > 0 && confidence < 1) {
+		confidence = 0.95
+	}
+
+	sentiments, processed := sia.tokenSentiments(text, nil, nil)
+	result := UncertainScores{SentimentScores: sia.scoreValence(sentiments, processed)}
+
+	// lexicon words with ratings which valence is resampled
+	var words []LexiconEntry
+	seen := make(map[string]bool)
+	for _, word := range sia.sentiText(processed).WordsAndEmoticonsLower {
+		if entry, ok := sia.lexicon[word]; ok && len(entry.Ratings) > 0 && !seen[word] {
+			seen[word] = true
+			words = append(words, entry)
+		}
+	}
+
+	if len(words) == 0 {
+		result.NegInterval = Interval{result.Neg, result.Neg}
+		result.NeuInterval = Interval{result.Neu, result.Neu}
+		result.PosInterval = Interval{result.Pos, result.Pos}
+		result.CompoundInterval = Interval{result.Compound, result.Compound}
+		return result
+	}
+
+	rnd := rand.New(rand.NewSource(opts.Seed))
+	neg := make([]float64, samples)
+	neu := make([]float64, samples)
+	pos := make([]float64, samples)
+	compound := make([]float64, samples)
+
+	valences := make(map[string]float64, len(words))
+	for i := 0; i < samples; i++ {
+		for _, entry := range words {
+			sum := 0.0
+			for range entry.Ratings {
+				sum += entry.Ratings[rnd.Intn(len(entry.Ratings))]
+			}
+			valences[entry.Token] = sum / float64(len(entry.Ratings))
+		}
+
+		sampleSentiments, _ := sia.tokenSentiments(text, nil, valences)
+		scores := sia.scoreValence(sampleSentiments, processed)
+		neg[i], neu[i], pos[i], compound[i] = scores.Neg, scores.Neu, scores.Pos, scores.Compound
+	}
+
+	result.NegInterval = percentileInterval(neg, confidence, 3)
+	result.NeuInterval = percentileInterval(neu, confidence, 3)
+	result.PosInterval = percentileInterval(pos, confidence, 3)
+	result.CompoundInterval = percentileInterval(compound, confidence, 4)
+
+	return result
+}
+
+// central interval containing confidence share of values, bounds are rounded to prec digits
+func percentileInterval(values []float64, confidence float64, prec int) Interval {
+	sort.Float64s(values)
+
+	tail := (1 - confidence) / 2
+	low := int(math.Floor(tail * float64(len(values)-1)))
+	high := int(math.Ceil((1 - tail) * float64(len(values)-1)))
+
+	return Interval{Low: floats.Round(values[low], prec), High: floats.Round(values[high], prec)}
+}
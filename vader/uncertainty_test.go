@@ -0,0 +1,43 @@
+package vader
+
+import (
+	"testing"
+)
+
+func TestAnalyzer_PolarityScoresWithUncertainty(t *testing.T) {
+	sia, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := UncertaintyOptions{Samples: 500, Seed: 1}
+	for _, sentence := range sentences {
+		scores := sia.PolarityScoresWithUncertainty(sentence, opts)
+		if scores.SentimentScores != sia.PolarityScores(sentence) {
+			t.Errorf("%s: point estimate %+v differs from polarity scores", sentence, scores.SentimentScores)
+		}
+		if scores.CompoundInterval.Low > scores.CompoundInterval.High || scores.PosInterval.Low > scores.PosInterval.High {
+			t.Errorf("%s: invalid intervals %+v", sentence, scores)
+		}
+		if scores != sia.PolarityScoresWithUncertainty(sentence, opts) {
+			t.Errorf("%s: intervals not reproducible with the same seed", sentence)
+		}
+	}
+
+	// raters agreed that "horrible" is negative
+	horrible := sia.PolarityScoresWithUncertainty("The service was horrible", opts)
+	if horrible.CompoundInterval.High >= 0 || !horrible.CompoundInterval.Contains(horrible.Compound) {
+		t.Errorf("expected confidently negative interval: %+v", horrible)
+	}
+
+	// raters disagreed about "nah" (ratings from -3 to 2)
+	nah := sia.PolarityScoresWithUncertainty("nah", opts)
+	if nah.CompoundInterval.Low >= 0 || nah.CompoundInterval.High <= 0 {
+		t.Errorf("expected borderline interval: %+v", nah)
+	}
+
+	neutral := sia.PolarityScoresWithUncertainty("The table is brown", opts)
+	if neutral.CompoundInterval != (Interval{0, 0}) || neutral.NeuInterval != (Interval{1, 1}) {
+		t.Errorf("expected degenerate intervals for neutral text: %+v", neutral)
+	}
+}
@@ -1,16 +1,23 @@
 package vader
 
 import (
-	"log"
+	"errors"
+	"fmt"
 	"math"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Normalize the score to be between -1 and 1 using an alpha that
 // approximates the max expected value
 func Normalize(score float64) float64 {
-	normalizedScore := score / math.Sqrt((score*score)+float64(Alpha))
+	return normalize(score, Alpha)
+}
+
+func normalize(score, alpha float64) float64 {
+	normalizedScore := score / math.Sqrt((score*score)+alpha)
 
 	if normalizedScore < -1.0 {
 		return -1.0
@@ -26,15 +33,26 @@ func Normalize(score float64) float64 {
 //Does not preserve punc-plus-letter emoticons (e.g. :D)
 //Returns list of clean words from text
 func CleanWordsAndEmoticons(text string) []string {
+	return cleanWordsAndEmoticons(text, nil)
+}
+
+// clean words of text, keeping emoticons recognized by isEmoticon whole,
+// e.g. ":D" of ":D!" or "(-:O" of "(-:O,"
+func cleanWordsAndEmoticons(text string, isEmoticon func(token string) bool) []string {
 	words := strings.Fields(text)
 
 	cleanWords := make([]string, 0, len(words))
 	for _, word := range words {
-		cleanWord := strings.TrimFunc(word, func(r rune) bool {
-			return PunctuationRegexp.Match([]byte{byte(r)}) || r == '‘' || r == '’'
-		})
+		if isEmoticon != nil {
+			if emoticon := findEmoticon(word, isEmoticon); emoticon != "" {
+				cleanWords = append(cleanWords, emoticon)
+				continue
+			}
+		}
 
-		if len(cleanWord) <= 2 {
+		cleanWord := strings.TrimFunc(word, isPunctuation)
+
+		if utf8.RuneCountInString(cleanWord) <= 2 {
 			cleanWords = append(cleanWords, word)
 		} else {
 			cleanWords = append(cleanWords, cleanWord)
@@ -44,6 +62,62 @@ func CleanWordsAndEmoticons(text string) []string {
 	return cleanWords
 }
 
+// find the longest emoticon in word which is surrounded only by punctuation
+func findEmoticon(word string, isEmoticon func(token string) bool) string {
+	// byte offsets where emoticon can start and end
+	starts := []int{0}
+	for i, r := range word {
+		if !isPunctuation(r) {
+			break
+		}
+		starts = append(starts, i+utf8.RuneLen(r))
+	}
+	ends := []int{len(word)}
+	for end := len(word); end > 0; {
+		r, size := utf8.DecodeLastRuneInString(word[:end])
+		if !isPunctuation(r) {
+			break
+		}
+		end -= size
+		ends = append(ends, end)
+	}
+
+	var emoticon string
+	for _, start := range starts {
+		for _, end := range ends {
+			if end-start > len(emoticon) && isEmoticon(word[start:end]) {
+				emoticon = word[start:end]
+			}
+		}
+	}
+
+	return emoticon
+}
+
+// ASCII punctuation and symbols of PunctuationRegexp and Unicode punctuation,
+// such as « » ¡ ¿ — … and full-width marks; other symbols like emoji are kept
+func isPunctuation(r rune) bool {
+	if r < utf8.RuneSelf {
+		return asciiPunctuation[r]
+	}
+
+	return unicode.IsPunct(r)
+}
+
+// ASCII characters matched by PunctuationRegexp
+var asciiPunctuation = func() (punctuation [utf8.RuneSelf]bool) {
+	for r := range punctuation {
+		punctuation[r] = PunctuationRegexp.MatchString(string(rune(r)))
+	}
+
+	return punctuation
+}()
+
+// check whether word has cased letters, all of them upper case, e.g. "GREAT" or ":D"
+func isAllCaps(word string) bool {
+	return word == strings.ToUpper(word) && word != strings.ToLower(word)
+}
+
 //Check whether just some words in the input are ALL CAPS
 func IsAllCapDiff(words []string) bool {
 	for _, word := range words {
@@ -64,60 +138,185 @@ func ReplacePercentages(text string) string {
 	return text
 }
 
-//Convert lexicon file data to map
-func MakeLexiconMap(lexicon string) map[string]float64 {
-	lexiconDict := make(map[string]float64)
+// ParseMode controls how lexicon loaders treat malformed lines
+type ParseMode int
 
-	for _, line := range strings.Split(strings.TrimSuffix(lexicon, "\n"), "\n") {
-		line = strings.TrimSpace(line)
-		values := strings.Split(line, "\t")
+const (
+	// Strict mode fails on the first malformed line
+	Strict ParseMode = iota
+	// Lenient mode skips malformed lines and reports them
+	Lenient
+)
+
+// ParseError describes a malformed line of a lexicon
+type ParseError struct {
+	Name    string // lexicon name, e.g. file name
+	Line    int    // 1-based line number
+	Content string // content of the malformed line
+	Err     error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("vader: %s:%d: %v: %q", e.Name, e.Line, e.Err, e.Content)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+//Convert lexicon file data to map of mean valences
+//In strict mode returns *ParseError for the first malformed line,
+//in lenient mode skips malformed lines and returns them in list
+func MakeLexiconMap(name, lexicon string, mode ParseMode) (map[string]float64, []*ParseError, error) {
+	entries, skipped, err := MakeLexiconEntries(name, lexicon, mode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lexiconDict := make(map[string]float64, len(entries))
+	for token, entry := range entries {
+		lexiconDict[token] = entry.Mean
+	}
+
+	return lexiconDict, skipped, nil
+}
+
+//Convert lexicon file data to map of full lexicon entries,
+//standard deviation and raw ratings columns are optional
+//In strict mode returns *ParseError for the first malformed line,
+//in lenient mode skips malformed lines and returns them in list
+func MakeLexiconEntries(name, lexicon string, mode ParseMode) (map[string]LexiconEntry, []*ParseError, error) {
+	entries := make(map[string]LexiconEntry)
+
+	skipped, err := parseLexiconLines(name, lexicon, mode, func(values []string) error {
+		if len(values) < 2 {
+			return errors.New("missing valence column")
+		}
+
+		entry := LexiconEntry{Token: values[0]}
 
-		word := values[0]
-		measure, err := strconv.ParseFloat(values[1], 64)
+		var err error
+		entry.Mean, err = strconv.ParseFloat(values[1], 64)
 		if err != nil {
-			log.Fatal(err)
+			return fmt.Errorf("invalid valence %q", values[1])
+		}
+
+		if len(values) > 2 {
+			entry.StdDev, err = strconv.ParseFloat(values[2], 64)
+			if err != nil {
+				return fmt.Errorf("invalid standard deviation %q", values[2])
+			}
+		}
+
+		if len(values) > 3 {
+			entry.Ratings, err = parseRatings(values[3])
+			if err != nil {
+				return err
+			}
 		}
 
-		lexiconDict[word] = measure
+		entries[entry.Token] = entry
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return entries, skipped, nil
+}
+
+// parse raw ratings column, e.g. "[-1, 0, 2]"
+func parseRatings(column string) ([]float64, error) {
+	if !strings.HasPrefix(column, "[") || !strings.HasSuffix(column, "]") {
+		return nil, fmt.Errorf("invalid ratings %q", column)
+	}
+
+	fields := strings.Split(strings.TrimSuffix(strings.TrimPrefix(column, "["), "]"), ",")
+	ratings := make([]float64, 0, len(fields))
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		rating, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rating %q", field)
+		}
+		ratings = append(ratings, rating)
 	}
 
-	return lexiconDict
+	return ratings, nil
 }
 
 // Convert emoji lexicon file data to map
-func MakeEmojiLexiconMap(emojiLexicon string) map[string]string {
+//In strict mode returns *ParseError for the first malformed line,
+//in lenient mode skips malformed lines and returns them in list
+func MakeEmojiLexiconMap(name, emojiLexicon string, mode ParseMode) (map[string]string, []*ParseError, error) {
 	emojiLexiconDict := make(map[string]string)
 
-	for _, line := range strings.Split(emojiLexicon, "\n") {
-		line = strings.TrimSpace(line)
-		values := strings.Split(line, "\t")
+	skipped, err := parseLexiconLines(name, emojiLexicon, mode, func(values []string) error {
+		if len(values) < 2 {
+			return errors.New("missing description column")
+		}
+
+		emojiLexiconDict[values[0]] = values[1]
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
 
-		word := values[0]
-		description := values[1]
+	return emojiLexiconDict, skipped, nil
+}
+
+// split lexicon to tab separated lines and pass values to parse function,
+// blank lines are ignored
+func parseLexiconLines(name, lexicon string, mode ParseMode, parse func(values []string) error) ([]*ParseError, error) {
+	var skipped []*ParseError
+
+	for i, line := range strings.Split(lexicon, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
 
-		emojiLexiconDict[word] = description
+		values := strings.Split(line, "\t")
+		if err := parse(values); err != nil {
+			parseErr := &ParseError{Name: name, Line: i + 1, Content: line, Err: err}
+			if mode == Strict {
+				return nil, parseErr
+			}
+			skipped = append(skipped, parseErr)
+		}
 	}
 
-	return emojiLexiconDict
+	return skipped, nil
 }
 
 // Determine if input contains negation words
 func ContainsNegation(inputWords []string) bool {
+	isNegation := func(word string) bool {
+		return containsWord(english.Negations, word)
+	}
+
+	return containsNegation(isNegation, english, IncludeNt, inputWords)
+}
+
+func containsNegation(isNegation func(word string) bool, language *LanguagePack, includeNt bool, inputWords []string) bool {
 	for i, word := range inputWords {
-		for _, negWord := range Negations {
-			if negWord == word {
-				return true
-			}
+		if isNegation(word) {
+			return true
 		}
 
-		if word == "least" {
-			if i > 0 && inputWords[i-1] != "at" && inputWords[i-1] != "very" {
+		if exceptions, ok := language.ConditionalNegations[word]; ok {
+			if i > 0 && !containsWord(exceptions, inputWords[i-1]) {
 				return true
 			}
 		}
 
-		if IncludeNt {
-			if strings.Contains(word, "n't") {
+		if includeNt && language.NegationSuffix != "" {
+			if strings.Contains(word, language.NegationSuffix) {
 				return true
 			}
 		}
@@ -0,0 +1,55 @@
+package vader
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCleanWordsAndEmoticons(t *testing.T) {
+	tests := map[string][]string{
+		// ASCII punctuation and emoticons
+		"VADER is smart, handsome, and funny!": {"VADER", "is", "smart", "handsome", "and", "funny"},
+		"I'm (really) happy :) :-(":            {"I'm", "really", "happy", ":)", ":-("},
+		"'quoted' \"words\"":                   {"quoted", "words"},
+
+		// accented Latin words
+		"¡Qué película tan buena!":    {"Qué", "película", "tan", "buena"},
+		"¿Dónde está el baño?":        {"Dónde", "está", "el", "baño"},
+		"«Très bien», dit-il…":        {"Très", "bien", "dit-il"},
+		"„Schön“ und ‚übel‘ — gewiß.": {"Schön", "und", "übel", "—", "gewiß"},
+		"ġood Ĭndeed":                 {"ġood", "Ĭndeed"},
+		"naïve café, déjà-vu":         {"naïve", "café", "déjà-vu"},
+
+		// non-Latin words, which letters have low bytes of ASCII punctuation
+		"Это молоко, а не кефир.": {"Это", "молоко", "а", "не", "кефир"},
+		"ПОЧЕМУ? Очень хорошо!":   {"ПОЧЕМУ", "Очень", "хорошо"},
+		"Καλημέρα! Ψυχή;":         {"Καλημέρα", "Ψυχή"},
+		"「素晴らしい」映画でした。":           {"素晴らしい」映画でした"},
+		"とても良い！ 最高だ！":             {"とても良い", "最高だ"},
+		"رائع، جميل!":             {"رائع", "جميل"},
+
+		// symbols other than ASCII are kept
+		"100€! ©2020. ✓✓✓": {"100€", "©2020", "✓✓✓"},
+	}
+
+	for text, expected := range tests {
+		if words := CleanWordsAndEmoticons(text); !reflect.DeepEqual(words, expected) {
+			t.Errorf("unexpected words of %q: %q, expected %q", text, words, expected)
+		}
+	}
+}
+
+func TestCleanWordsAndEmoticons_Emoticons(t *testing.T) {
+	emoticons := map[string]bool{":D": true, "(-:O": true, "8-)": true, ":)": true}
+	isEmoticon := func(token string) bool {
+		return emoticons[token]
+	}
+
+	text := "Great :D! (-:O, (8-)) :)..."
+	if words := cleanWordsAndEmoticons(text, isEmoticon); !reflect.DeepEqual(words, []string{"Great", ":D", "(-:O", "8-)", ":)"}) {
+		t.Errorf("unexpected words: %q", words)
+	}
+	if words := CleanWordsAndEmoticons(text); !reflect.DeepEqual(words, []string{"Great", ":D!", "(-:O,", "(8-))", ":)..."}) {
+		t.Errorf("unexpected words without emoticons: %q", words)
+	}
+}